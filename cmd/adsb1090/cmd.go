@@ -8,14 +8,18 @@ import (
 
 	"github.com/landru29/adsb1090/internal/aircraftdb"
 	"github.com/landru29/adsb1090/internal/application"
+	"github.com/landru29/adsb1090/internal/binary"
 	conf "github.com/landru29/adsb1090/internal/config"
 	"github.com/landru29/adsb1090/internal/database"
 	"github.com/landru29/adsb1090/internal/logger"
+	"github.com/landru29/adsb1090/internal/metrics"
 	"github.com/landru29/adsb1090/internal/model"
 	"github.com/landru29/adsb1090/internal/processor"
 	"github.com/landru29/adsb1090/internal/processor/decoder"
 	"github.com/landru29/adsb1090/internal/serialize"
 	"github.com/landru29/adsb1090/internal/serialize/nmea"
+	"github.com/landru29/adsb1090/internal/stats"
+	"github.com/landru29/adsb1090/internal/transport"
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +28,7 @@ func rootCommand() (*cobra.Command, error) { //nolint: funlen
 		app                  *application.App
 		availableSerializers []serialize.Serializer
 		config               *conf.Config
+		statistics           = stats.New()
 	)
 
 	rootCommand := &cobra.Command{
@@ -35,9 +40,12 @@ func rootCommand() (*cobra.Command, error) { //nolint: funlen
 
 			log := slog.New(slog.NewTextHandler(cmd.OutOrStdout(), nil))
 
-			ctx := logger.WithLogger(
-				cmd.Context(),
-				log,
+			ctx := stats.WithStats(
+				logger.WithLogger(
+					cmd.Context(),
+					log,
+				),
+				statistics,
 			)
 
 			cmd.SetContext(ctx)
@@ -48,9 +56,20 @@ func rootCommand() (*cobra.Command, error) { //nolint: funlen
 				database.ElementWithCleanCycle[model.ICAOAddr, model.Aircraft](config.DatabaseLifetime),
 			)
 
+			var metricsCollector *metrics.Collector
+
+			if config.HTTPMetrics {
+				metricsCollector = provideMetrics(log, config, statistics, aircraftDB)
+			}
+
 			var serializers map[string]serialize.Serializer
 
-			serializers, availableSerializers = provideSerializers(log, nmea.VesselType(config.NmeaVessel), config.NmeaMid)
+			serializers, availableSerializers = provideSerializers(
+				log,
+				nmea.VesselType(config.NmeaVessel),
+				config.NmeaMid,
+				config.Staleness.Model(config.OmitStale),
+			)
 
 			transporters, err := provideTransporters(
 				ctx,
@@ -58,6 +77,8 @@ func rootCommand() (*cobra.Command, error) { //nolint: funlen
 				availableSerializers,
 				serializers,
 				aircraftDB,
+				statistics,
+				metricsCollector,
 				config.HTTPConf,
 				config.UDPConf,
 				config.TCPConf,
@@ -68,13 +89,52 @@ func rootCommand() (*cobra.Command, error) { //nolint: funlen
 				return err
 			}
 
+			var errorCorrector *binary.ErrorCorrector
+			if config.CRCFixBits > 0 {
+				errorCorrector = binary.NewErrorCorrector(int(config.CRCFixBits))
+			}
+
 			decoderCfg := []decoder.Configurator{
 				decoder.WithDatabaseLifetime(config.DatabaseLifetime),
+				decoder.WithErrorCorrector(errorCorrector),
+				decoder.WithStats(statistics),
 			}
+
+			if receiver := config.ReceiverLocation(); receiver != nil {
+				decoderCfg = append(decoderCfg, decoder.WithReceiver(*receiver))
+			}
+
+			if config.StrictReference {
+				decoderCfg = append(decoderCfg, decoder.WithStrictReference())
+			}
+
 			for _, transporter := range transporters {
 				log.Info("loading transporter", "name", transporter.String())
 
 				decoderCfg = append(decoderCfg, decoder.WithTransporter(transporter))
+
+				if clientCounter, ok := transporter.(metrics.ClientCounter); ok {
+					metricsCollector.AddTransporter(clientCounter)
+				}
+
+				if eventTransporter, ok := transporter.(transport.EventTransporter); ok {
+					decoderCfg = append(decoderCfg, decoder.WithEventTransporter(eventTransporter))
+				}
+			}
+
+			frameTransporters, err := provideFrameTransporters(ctx, log, config.BeastConf, config.AVRConf)
+			if err != nil {
+				return err
+			}
+
+			for _, transporter := range frameTransporters {
+				log.Info("loading frame transporter", "name", transporter.String())
+
+				decoderCfg = append(decoderCfg, decoder.WithFrameTransporter(transporter))
+
+				if clientCounter, ok := transporter.(metrics.ClientCounter); ok {
+					metricsCollector.AddTransporter(clientCounter)
+				}
 			}
 
 			log.Info("loading aircraft database", "from", config.AircraftDatabaseFile())
@@ -93,17 +153,24 @@ func rootCommand() (*cobra.Command, error) { //nolint: funlen
 			log.Info("found", "count", len(aircraftWorldDatabase))
 			decoderCfg = append(decoderCfg, decoder.WithAircraftWorldDatabase(aircraftWorldDatabase))
 
+			decoderProcess := decoder.New(
+				ctx,
+				log,
+				decoderCfg...,
+			)
+
+			for name, storage := range decoderProcess.Storages() {
+				metricsCollector.AddStorage("decoder."+name, storage)
+			}
+
 			app, err = application.New(
 				log,
 				config,
 				[]processor.Processer{
-					decoder.New(
-						ctx,
-						log,
-						decoderCfg...,
-					),
+					decoderProcess,
 					// raw.New(log),
 				},
+				application.WithErrorCorrector(errorCorrector),
 			)
 
 			return err
@@ -111,6 +178,8 @@ func rootCommand() (*cobra.Command, error) { //nolint: funlen
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
+			logStats(ctx, config, statistics)
+
 			if err := app.Start(ctx); err != nil {
 				return err
 			}
@@ -132,6 +201,7 @@ func rootCommand() (*cobra.Command, error) { //nolint: funlen
 		aircraftCommand(config),
 		serializerCommand(&availableSerializers),
 		configCommand(config),
+		statsCommand(&app, config, statistics, rootCommand.Flags()),
 	)
 
 	return rootCommand, nil
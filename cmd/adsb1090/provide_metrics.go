@@ -0,0 +1,36 @@
+package main
+
+import (
+	"log/slog"
+
+	conf "github.com/landru29/adsb1090/internal/config"
+	"github.com/landru29/adsb1090/internal/database"
+	"github.com/landru29/adsb1090/internal/metrics"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/stats"
+)
+
+func provideMetrics(
+	log *slog.Logger,
+	config *conf.Config,
+	statistics *stats.Stats,
+	aircraftDB *database.ElementStorage[model.ICAOAddr, model.Aircraft],
+) *metrics.Collector {
+	if config.HTTPConf.Addr == "" {
+		log.Warn("the metrics are only served with --http")
+	}
+
+	opts := []metrics.Configurator{
+		metrics.WithStats(statistics),
+		metrics.WithAircraftDatabase(aircraftDB),
+	}
+
+	if receiver := config.ReceiverLocation(); receiver != nil {
+		opts = append(opts, metrics.WithReceiver(*receiver))
+	}
+
+	output := metrics.New(opts...)
+	output.AddStorage("aircraft", aircraftDB)
+
+	return output
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"log/slog"
 
+	"github.com/landru29/adsb1090/internal/model"
 	"github.com/landru29/adsb1090/internal/serialize"
 	"github.com/landru29/adsb1090/internal/serialize/basestation"
 	"github.com/landru29/adsb1090/internal/serialize/json"
@@ -15,15 +16,16 @@ func provideSerializers(
 	log *slog.Logger,
 	nmeaVessel nmea.VesselType,
 	nmeaMid uint16,
+	staleness model.Staleness,
 ) (map[string]serialize.Serializer, []serialize.Serializer) {
 	serializers := map[string]serialize.Serializer{}
 
 	availableSerializers := []serialize.Serializer{
 		none.Serializer{},
-		json.Serializer{},
-		text.Serializer{},
-		basestation.Serializer{},
-		nmea.New(nmeaVessel, nmeaMid),
+		json.Serializer{Staleness: staleness},
+		text.Serializer{Staleness: staleness},
+		basestation.Serializer{Staleness: staleness},
+		nmea.New(nmeaVessel, nmeaMid, staleness),
 	}
 
 	for _, serializer := range availableSerializers {
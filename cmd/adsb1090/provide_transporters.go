@@ -8,8 +8,10 @@ import (
 
 	"github.com/landru29/adsb1090/internal/config"
 	"github.com/landru29/adsb1090/internal/database"
+	"github.com/landru29/adsb1090/internal/metrics"
 	"github.com/landru29/adsb1090/internal/model"
 	"github.com/landru29/adsb1090/internal/serialize"
+	"github.com/landru29/adsb1090/internal/stats"
 	"github.com/landru29/adsb1090/internal/transport"
 	"github.com/landru29/adsb1090/internal/transport/file"
 	"github.com/landru29/adsb1090/internal/transport/http"
@@ -23,6 +25,8 @@ func provideTransporters(
 	availableSerializers []serialize.Serializer,
 	serializers map[string]serialize.Serializer,
 	aircraftDB *database.ElementStorage[model.ICAOAddr, model.Aircraft],
+	statistics *stats.Stats,
+	metricsCollector *metrics.Collector,
 	httpConf config.HTTPConfig,
 	udpConf net.ProtocolConfig,
 	tcpConf net.ProtocolConfig,
@@ -32,7 +36,22 @@ func provideTransporters(
 	transporters := []transport.Transporter{}
 
 	if httpConf.Addr != "" {
-		httpTransport, err := http.New(ctx, httpConf.Addr, httpConf.APIPath, aircraftDB, availableSerializers)
+		httpOpts := []http.Configurator{
+			http.WithStats(statistics),
+		}
+
+		if metricsCollector != nil {
+			httpOpts = append(httpOpts, http.WithMetrics(metricsCollector))
+		}
+
+		httpTransport, err := http.New(
+			ctx,
+			httpConf.Addr,
+			httpConf.APIPath,
+			aircraftDB,
+			availableSerializers,
+			httpOpts...,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -92,3 +111,32 @@ func provideTransporters(
 
 	return transporters, nil
 }
+
+func provideFrameTransporters(
+	ctx context.Context,
+	log *slog.Logger,
+	beastConf net.ProtocolConfig,
+	avrConf net.ProtocolConfig,
+) ([]transport.FrameTransporter, error) {
+	transporters := []transport.FrameTransporter{}
+
+	if beastConf.IsValid() {
+		beastTransport, err := net.NewBeast(ctx, beastConf, log)
+		if err != nil {
+			return nil, err
+		}
+
+		transporters = append(transporters, beastTransport)
+	}
+
+	if avrConf.IsValid() {
+		avrTransport, err := net.NewAVR(ctx, avrConf, log)
+		if err != nil {
+			return nil, err
+		}
+
+		transporters = append(transporters, avrTransport)
+	}
+
+	return transporters, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/application"
+	conf "github.com/landru29/adsb1090/internal/config"
+	"github.com/landru29/adsb1090/internal/logger"
+	"github.com/landru29/adsb1090/internal/stats"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const defaultStatsRefresh = 10 * time.Second
+
+// statsCommand runs the receiver like the main command, with the same flags.
+func statsCommand(
+	app **application.App,
+	config *conf.Config,
+	statistics *stats.Stats,
+	flags *pflag.FlagSet,
+) *cobra.Command {
+	var refresh time.Duration
+
+	output := &cobra.Command{
+		Use:   "stats",
+		Short: "stats",
+		Long:  "run the receiver and display its statistics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			logStats(ctx, config, statistics)
+
+			errChan := make(chan error, 1)
+
+			go func() {
+				errChan <- (*app).Start(ctx)
+			}()
+
+			ticker := time.NewTicker(refresh)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case err := <-errChan:
+					cmd.Println(statistics.Report().String())
+
+					return err
+				case <-ticker.C:
+					cmd.Println(statistics.Report().String())
+				}
+			}
+		},
+	}
+
+	output.Flags().AddFlagSet(flags)
+
+	output.Flags().DurationVarP(
+		&refresh,
+		"refresh",
+		"r",
+		defaultStatsRefresh,
+		"display period",
+	)
+
+	return output
+}
+
+// logStats periodically logs the statistics of the running receiver.
+func logStats(ctx context.Context, config *conf.Config, statistics *stats.Stats) {
+	log, found := logger.Logger(ctx)
+	if !found || config.StatsInterval <= 0 {
+		return
+	}
+
+	go statistics.Log(ctx, log, config.StatsInterval)
+}
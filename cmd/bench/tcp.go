@@ -65,7 +65,7 @@ func tcpBindCommand() *cobra.Command {
 				Track:       &track,
 			}
 
-			serializer := nmea.New(nmea.VesselTypeHelicopter, 0)
+			serializer := nmea.New(nmea.VesselTypeHelicopter, 0, model.DefaultStaleness())
 
 			go func() {
 				for {
@@ -3,19 +3,37 @@ package application
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"os"
+	"strings"
 
+	"github.com/landru29/adsb1090/internal/binary"
 	"github.com/landru29/adsb1090/internal/config"
+	"github.com/landru29/adsb1090/internal/errors"
 	"github.com/landru29/adsb1090/internal/input"
 	"github.com/landru29/adsb1090/internal/input/implementations"
 	"github.com/landru29/adsb1090/internal/processor"
 )
 
+const (
+	// ErrInvalidBeastInput is when the Beast input cannot be parsed.
+	ErrInvalidBeastInput errors.Error = "invalid beast input"
+	// ErrInvalidAVRInput is when the AVR input cannot be parsed.
+	ErrInvalidAVRInput errors.Error = "invalid AVR input"
+
+	errInvalidDirection errors.Error = "invalid direction"
+)
+
+// Configurator is the App configurator.
+type Configurator func(*App)
+
 // App is the main application.
 type App struct {
-	starter    input.Starter
-	log        *slog.Logger
-	processors []processor.Processer
+	starter        input.Starter
+	log            *slog.Logger
+	processors     []processor.Processer
+	errorCorrector *binary.ErrorCorrector
 }
 
 // New creates a new application.
@@ -23,6 +41,7 @@ func New(
 	log *slog.Logger,
 	cfg *config.Config,
 	processors []processor.Processer,
+	appOpts ...Configurator,
 ) (*App, error) {
 	log.Info("Initializing application")
 
@@ -33,6 +52,10 @@ func New(
 		processors: processors,
 	}
 
+	for _, opt := range appOpts {
+		opt(output)
+	}
+
 	switch {
 	case cfg.FixturesFilename != "":
 		// Source is a file
@@ -41,8 +64,42 @@ func New(
 			opts = append(opts, implementations.WithLoop())
 		}
 
+		if output.errorCorrector != nil {
+			opts = append(opts, implementations.WithErrorCorrector(output.errorCorrector))
+		}
+
 		output.starter = implementations.NewFile(cfg.FixturesFilename, opts...)
 
+		return output, nil
+	case cfg.BeastInput != "":
+		// Source is a Beast TCP stream
+		addr, opts, err := streamOptions(cfg.BeastInput)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s (should be like dial>192.168.1.10:30005)", ErrInvalidBeastInput, cfg.BeastInput)
+		}
+
+		output.starter = implementations.NewBeast(addr, opts...)
+
+		return output, nil
+	case cfg.AVRInput == "-":
+		// Source is AVR lines on stdin
+		output.starter = implementations.NewAVRReader(os.Stdin)
+
+		return output, nil
+	case strings.HasPrefix(cfg.AVRInput, "dial>") || strings.HasPrefix(cfg.AVRInput, "bind>"):
+		// Source is an AVR TCP stream
+		addr, opts, err := streamOptions(cfg.AVRInput)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s (should be like dial>192.168.1.10:30002)", ErrInvalidAVRInput, cfg.AVRInput)
+		}
+
+		output.starter = implementations.NewAVR(addr, opts...)
+
+		return output, nil
+	case cfg.AVRInput != "":
+		// Source is an AVR file
+		output.starter = implementations.NewAVRFile(cfg.AVRInput)
+
 		return output, nil
 	default:
 		opts := []implementations.RTL28Configurator{}
@@ -69,6 +126,30 @@ func New(
 	}
 }
 
+// WithErrorCorrector sets the CRC error corrector.
+func WithErrorCorrector(corrector *binary.ErrorCorrector) Configurator {
+	return func(a *App) {
+		a.errorCorrector = corrector
+	}
+}
+
+// streamOptions parses a stream input (syntax: 'direction>host:port').
+func streamOptions(str string) (string, []implementations.StreamConfigurator, error) {
+	direction, addr, found := strings.Cut(str, ">")
+	if !found {
+		return str, nil, nil
+	}
+
+	switch direction {
+	case "dial":
+		return addr, nil, nil
+	case "bind":
+		return addr, []implementations.StreamConfigurator{implementations.WithListen()}, nil
+	}
+
+	return "", nil, errInvalidDirection
+}
+
 // Start is the application entrypoint.
 func (a *App) Start(ctx context.Context) error {
 	a.log.Info("Starting application")
@@ -0,0 +1,111 @@
+// Package avr is the AVR text format.
+package avr
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/landru29/adsb1090/internal/errors"
+	"github.com/landru29/adsb1090/internal/model"
+)
+
+// Each frame is a line, in uppercase hexadecimal:
+//
+//	*8D4840D6202CC371C32CE0576098;
+//
+// or, with the 12 MHz timestamp (12 hexadecimal digits):
+//
+//	@0000001A2B3C8D4840D6202CC371C32CE0576098;
+
+const (
+	// ErrInvalidFormat is when a line is not an AVR frame.
+	ErrInvalidFormat errors.Error = "invalid AVR frame"
+
+	startFrame          = '*'
+	startTimestampFrame = '@'
+	endFrame            = ';'
+
+	timestampLength = 12
+
+	shortFrameSize = 7
+	longFrameSize  = 14
+)
+
+// Parse reads a frame from an AVR line.
+func Parse(line string) (model.Frame, error) {
+	line = strings.TrimSpace(line)
+
+	if len(line) < 2 || line[len(line)-1] != endFrame {
+		return model.Frame{}, fmt.Errorf("%w: %s", ErrInvalidFormat, line)
+	}
+
+	output := model.Frame{}
+
+	data := line[1 : len(line)-1]
+
+	switch line[0] {
+	case startFrame:
+	case startTimestampFrame:
+		if len(data) < timestampLength {
+			return model.Frame{}, fmt.Errorf("%w: %s", ErrInvalidFormat, line)
+		}
+
+		timestamp, err := strconv.ParseUint(data[:timestampLength], 16, 64)
+		if err != nil {
+			return model.Frame{}, fmt.Errorf("%w: %s", ErrInvalidFormat, line)
+		}
+
+		output.Timestamp = timestamp
+		data = data[timestampLength:]
+	default:
+		return model.Frame{}, fmt.Errorf("%w: %s", ErrInvalidFormat, line)
+	}
+
+	modes, err := hex.DecodeString(data)
+	if err != nil || (len(modes) != shortFrameSize && len(modes) != longFrameSize) {
+		return model.Frame{}, fmt.Errorf("%w: %s", ErrInvalidFormat, line)
+	}
+
+	output.ModeS = modes
+
+	return output, nil
+}
+
+// Marshal encodes a frame in an AVR line.
+func Marshal(frame model.Frame) []byte {
+	return []byte(fmt.Sprintf("%c%s%c\n", startFrame, frame.ModeS, endFrame))
+}
+
+// Reader reads AVR frames from a stream.
+type Reader struct {
+	scanner *bufio.Scanner
+}
+
+// NewReader creates an AVR frame reader.
+func NewReader(rd io.Reader) *Reader {
+	return &Reader{
+		scanner: bufio.NewScanner(rd),
+	}
+}
+
+// Next reads the next valid frame. Invalid lines are skipped.
+func (r *Reader) Next() (model.Frame, error) {
+	for r.scanner.Scan() {
+		frame, err := Parse(r.scanner.Text())
+		if err != nil {
+			continue
+		}
+
+		return frame, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return model.Frame{}, err
+	}
+
+	return model.Frame{}, io.EOF
+}
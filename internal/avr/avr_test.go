@@ -0,0 +1,122 @@
+package avr_test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/avr"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("frame", func(t *testing.T) {
+		t.Parallel()
+
+		frame, err := avr.Parse("*8D4840D6202CC371C32CE0576098;")
+		require.NoError(t, err)
+
+		assert.Equal(t, "8D4840D6202CC371C32CE0576098", frame.ModeS.String())
+		assert.Zero(t, frame.Timestamp)
+	})
+
+	t.Run("frame with timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		frame, err := avr.Parse("@0000001A2B3C5d4ca92bf0802f;\r\n")
+		require.NoError(t, err)
+
+		assert.Equal(t, "5D4CA92BF0802F", frame.ModeS.String())
+		assert.Equal(t, uint64(0x1A2B3C), frame.Timestamp)
+	})
+
+	for _, line := range []string{
+		"",
+		"*;",
+		"8D4840D6202CC371C32CE0576098;",
+		"*8D4840D6202CC371C32CE0576098",
+		"*8D4840D6202CC371C32CE05760;",
+		"*8D4840D6202CC371C32CE05760ZZ;",
+		"@0000001A5d4ca92bf0802f;",
+	} {
+		invalid := line
+
+		t.Run("invalid "+invalid, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := avr.Parse(invalid)
+			require.ErrorIs(t, err, avr.ErrInvalidFormat)
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	frame, err := avr.Parse("*8d4840d6202cc371c32ce0576098;")
+	require.NoError(t, err)
+
+	assert.Equal(t, "*8D4840D6202CC371C32CE0576098;\n", string(avr.Marshal(frame)))
+}
+
+func TestReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fixture", func(t *testing.T) {
+		t.Parallel()
+
+		file, err := os.Open("../model/testdata/dump1090.txt")
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = file.Close()
+		})
+
+		reader := avr.NewReader(file)
+
+		count := 0
+
+		for {
+			frame, err := reader.Next()
+			if err != nil {
+				require.ErrorIs(t, err, io.EOF)
+
+				break
+			}
+
+			require.NoError(t, frame.ModeS.CheckSum())
+
+			count++
+		}
+
+		assert.Positive(t, count)
+	})
+
+	t.Run("invalid lines are skipped", func(t *testing.T) {
+		t.Parallel()
+
+		reader := avr.NewReader(strings.NewReader("hello\n*8D4840D6202CC371C32CE0576098;\n\n*ZZ;\n*5D4CA92BF0802F;\n"))
+
+		frames := []model.ModeS{}
+
+		for {
+			frame, err := reader.Next()
+			if err != nil {
+				require.ErrorIs(t, err, io.EOF)
+
+				break
+			}
+
+			frames = append(frames, frame.ModeS)
+		}
+
+		require.Len(t, frames, 2)
+		assert.Equal(t, "8D4840D6202CC371C32CE0576098", frames[0].String())
+		assert.Equal(t, "5D4CA92BF0802F", frames[1].String())
+	})
+}
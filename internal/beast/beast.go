@@ -0,0 +1,207 @@
+// Package beast is the Beast binary format.
+package beast
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	localerrors "github.com/landru29/adsb1090/internal/errors"
+)
+
+// ┏━━━━━━┯━━━━━━┯━━━━━━━━━━━┯━━━━━━━━┯━━━━━━━━━━━━┓
+// ┃ 0x1a | type | timestamp | signal |    data    ┃
+// ┠┈┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┨
+// ┃  1   |  1   |     6     |   1    |  2, 7, 14  ┃
+// ┗━━━━━━┷━━━━━━┷━━━━━━━━━━━┷━━━━━━━━┷━━━━━━━━━━━━┛
+//
+// Each 0x1a byte after the type is doubled.
+
+// FrameType is the type of a Beast frame.
+type FrameType byte
+
+const (
+	// FrameTypeModeAC is a Mode A/C reply (2 bytes).
+	FrameTypeModeAC FrameType = '1'
+	// FrameTypeModeSShort is a Mode S short frame (7 bytes).
+	FrameTypeModeSShort FrameType = '2'
+	// FrameTypeModeSLong is a Mode S long frame (14 bytes).
+	FrameTypeModeSLong FrameType = '3'
+
+	// ErrTruncated is when a frame is interrupted by the start of the next one.
+	ErrTruncated localerrors.Error = "truncated beast frame"
+	// ErrUnsupportedLength is when the data cannot be sent in a Beast frame.
+	ErrUnsupportedLength localerrors.Error = "unsupported beast frame length"
+
+	escape = 0x1a
+
+	timestampSize = 6
+	signalSize    = 1
+)
+
+var dataSizes = map[FrameType]int{ //nolint: gochecknoglobals
+	FrameTypeModeAC:     2,
+	FrameTypeModeSShort: 7,
+	FrameTypeModeSLong:  14,
+}
+
+// Frame is a Beast frame.
+type Frame struct {
+	Type FrameType
+	// Timestamp is the 48 bits MLAT counter, running at 12 MHz.
+	Timestamp uint64
+	// Signal is the signal level (0-255).
+	Signal uint8
+	Data   []byte
+}
+
+// ModeS checks if the frame carries a Mode S message.
+func (f Frame) ModeS() bool {
+	return f.Type == FrameTypeModeSShort || f.Type == FrameTypeModeSLong
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f Frame) MarshalBinary() ([]byte, error) {
+	frameType := f.Type
+	if frameType == 0 {
+		for candidate, size := range dataSizes {
+			if size == len(f.Data) {
+				frameType = candidate
+			}
+		}
+	}
+
+	if size, found := dataSizes[frameType]; !found || size != len(f.Data) {
+		return nil, ErrUnsupportedLength
+	}
+
+	payload := make([]byte, 0, timestampSize+signalSize+len(f.Data))
+
+	for idx := timestampSize - 1; idx >= 0; idx-- {
+		payload = append(payload, byte(f.Timestamp>>(8*idx))) //nolint: gomnd
+	}
+
+	payload = append(payload, f.Signal)
+	payload = append(payload, f.Data...)
+
+	output := make([]byte, 0, 2*len(payload)+2) //nolint: gomnd
+	output = append(output, escape, byte(frameType))
+
+	for _, value := range payload {
+		if value == escape {
+			output = append(output, escape)
+		}
+
+		output = append(output, value)
+	}
+
+	return output, nil
+}
+
+// Reader reads Beast frames from a stream.
+type Reader struct {
+	reader      *bufio.Reader
+	pendingType FrameType
+}
+
+// NewReader creates a Beast frame reader.
+func NewReader(rd io.Reader) *Reader {
+	return &Reader{
+		reader: bufio.NewReader(rd),
+	}
+}
+
+// Next reads the next frame of a known type.
+func (r *Reader) Next() (Frame, error) {
+	for {
+		frameType, err := r.sync()
+		if err != nil {
+			return Frame{}, err
+		}
+
+		size, found := dataSizes[frameType]
+		if !found {
+			continue
+		}
+
+		payload, err := r.readEscaped(timestampSize + signalSize + size)
+		if errors.Is(err, ErrTruncated) {
+			continue
+		}
+
+		if err != nil {
+			return Frame{}, err
+		}
+
+		output := Frame{
+			Type:   frameType,
+			Signal: payload[timestampSize],
+			Data:   payload[timestampSize+signalSize:],
+		}
+
+		for _, value := range payload[:timestampSize] {
+			output.Timestamp = output.Timestamp<<8 | uint64(value) //nolint: gomnd
+		}
+
+		return output, nil
+	}
+}
+
+// sync looks for the start of the next frame, and gives its type.
+func (r *Reader) sync() (FrameType, error) {
+	if r.pendingType != 0 {
+		output := r.pendingType
+		r.pendingType = 0
+
+		return output, nil
+	}
+
+	for {
+		value, err := r.reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		if value != escape {
+			continue
+		}
+
+		value, err = r.reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		// an escaped 0x1a is data; we are not at the start of a frame.
+		if value != escape {
+			return FrameType(value), nil
+		}
+	}
+}
+
+func (r *Reader) readEscaped(size int) ([]byte, error) {
+	output := make([]byte, size)
+
+	for idx := range output {
+		value, err := r.reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		if value == escape {
+			value, err = r.reader.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+
+			if value != escape {
+				r.pendingType = FrameType(value)
+
+				return nil, ErrTruncated
+			}
+		}
+
+		output[idx] = value
+	}
+
+	return output, nil
+}
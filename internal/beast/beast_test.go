@@ -0,0 +1,102 @@
+package beast_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/beast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrame(t *testing.T) {
+	t.Parallel()
+
+	data, err := hex.DecodeString("8D4840D6202CC371C32CE0576098")
+	require.NoError(t, err)
+
+	t.Run("marshal", func(t *testing.T) {
+		t.Parallel()
+
+		output, err := beast.Frame{
+			Timestamp: 0x1a0000000102,
+			Signal:    0x1a,
+			Data:      data,
+		}.MarshalBinary()
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			"1a331a1a00000001021a1a8d4840d6202cc371c32ce0576098",
+			hex.EncodeToString(output),
+		)
+	})
+
+	t.Run("unsupported length", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := beast.Frame{Data: data[:5]}.MarshalBinary()
+		require.ErrorIs(t, err, beast.ErrUnsupportedLength)
+	})
+}
+
+func TestReader(t *testing.T) {
+	t.Parallel()
+
+	long, err := hex.DecodeString("8D4840D6202CC371C32CE0576098")
+	require.NoError(t, err)
+
+	short, err := hex.DecodeString("5D4CA92BF0802F")
+	require.NoError(t, err)
+
+	encode := func(frame beast.Frame) []byte {
+		output, err := frame.MarshalBinary()
+		require.NoError(t, err)
+
+		return output
+	}
+
+	stream := bytes.Join([][]byte{
+		// garbage before the first frame.
+		{0x00, 0x1a, 0x1a, 0x42},
+		encode(beast.Frame{Timestamp: 0x1a1a1a1a1a1a, Signal: 0x1a, Data: long}),
+		// Mode A/C.
+		encode(beast.Frame{Timestamp: 12000000, Signal: 100, Data: []byte{0x12, 0x34}}),
+		// status frame.
+		{0x1a, '4', 0x00, 0x01, 0x02},
+		// truncated frame.
+		encode(beast.Frame{Timestamp: 1, Signal: 2, Data: long})[:10],
+		encode(beast.Frame{Timestamp: 42, Signal: 200, Data: short}),
+	}, nil)
+
+	reader := beast.NewReader(bytes.NewReader(stream))
+
+	frame, err := reader.Next()
+	require.NoError(t, err)
+
+	assert.Equal(t, beast.FrameTypeModeSLong, frame.Type)
+	assert.Equal(t, uint64(0x1a1a1a1a1a1a), frame.Timestamp)
+	assert.Equal(t, uint8(0x1a), frame.Signal)
+	assert.Equal(t, long, frame.Data)
+	assert.True(t, frame.ModeS())
+
+	frame, err = reader.Next()
+	require.NoError(t, err)
+
+	assert.Equal(t, beast.FrameTypeModeAC, frame.Type)
+	assert.Equal(t, uint64(12000000), frame.Timestamp)
+	assert.False(t, frame.ModeS())
+
+	frame, err = reader.Next()
+	require.NoError(t, err)
+
+	assert.Equal(t, beast.FrameTypeModeSShort, frame.Type)
+	assert.Equal(t, uint64(42), frame.Timestamp)
+	assert.Equal(t, uint8(200), frame.Signal)
+	assert.Equal(t, short, frame.Data)
+
+	_, err = reader.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -17,7 +17,7 @@ func ReadBits(data []byte, bitCursor uint64, count uint8) uint64 {
 		if shift > 0 {
 			out |= (uint64((data[byteIdx] & mask)) << shift)
 		} else {
-			out |= (uint64(data[byteIdx]) >> -shift)
+			out |= (uint64(data[byteIdx]&mask) >> -shift)
 		}
 
 		if shift <= 0 {
@@ -45,6 +45,18 @@ func TestReadBits(t *testing.T) {
 
 		assert.Equal(t, uint64(0x773b6), bits)
 	})
+
+	t.Run("inside a byte", func(t *testing.T) {
+		t.Parallel()
+
+		// 10111010 11010011
+		//            010
+		data := []byte{0xba, 0xd3}
+
+		bits := binary.ReadBits(data, 10, 3)
+
+		assert.Equal(t, uint64(0x2), bits)
+	})
 }
 
 func TestWriteBits(t *testing.T) {
@@ -0,0 +1,157 @@
+package binary
+
+import (
+	"sync/atomic"
+
+	"github.com/landru29/adsb1090/internal/errors"
+)
+
+const (
+	// ErrUncorrectable is when the frame cannot be repaired.
+	ErrUncorrectable errors.Error = "uncorrectable frame"
+
+	// MaxCorrectableBits is the maximum number of bit errors that can be repaired.
+	MaxCorrectableBits = 2
+
+	parityBitSize         = 24
+	downlinkFormatBitSize = 5
+	shortFrameBitSize     = 56
+	longFrameBitSize      = 112
+)
+
+// ErrorCorrector repairs frames with bit errors, using the CRC syndrome.
+type ErrorCorrector struct {
+	maxBits   int
+	syndromes map[int]map[uint32][]int
+	corrected atomic.Uint64
+}
+
+// NewErrorCorrector creates an error corrector for up to maxBits errors per frame.
+func NewErrorCorrector(maxBits int) *ErrorCorrector {
+	if maxBits > MaxCorrectableBits {
+		maxBits = MaxCorrectableBits
+	}
+
+	output := &ErrorCorrector{
+		maxBits:   maxBits,
+		syndromes: map[int]map[uint32][]int{},
+	}
+
+	for _, bitLength := range []int{shortFrameBitSize, longFrameBitSize} {
+		output.syndromes[bitLength] = syndromeTable(bitLength, maxBits)
+	}
+
+	return output
+}
+
+// Syndrome is the xor between the computed checksum and the parity of a frame.
+// It is zero when the frame has no error.
+func Syndrome(frame []byte) uint32 {
+	length := len(frame)
+
+	parity := (uint32(frame[length-3]) << 16) | (uint32(frame[length-2]) << 8) | uint32(frame[length-1]) //nolint: gomnd
+
+	return ChecksumSquitter(frame[:length-3]) ^ parity
+}
+
+// Correct repairs the frame in place and gives the number of fixed bits.
+// The downlink format bits are never modified.
+func (c *ErrorCorrector) Correct(frame []byte) (int, error) {
+	syndrome := Syndrome(frame)
+	if syndrome == 0 {
+		return 0, nil
+	}
+
+	if c == nil {
+		return 0, ErrUncorrectable
+	}
+
+	positions, found := c.syndromes[len(frame)*8][syndrome]
+	if !found {
+		return 0, ErrUncorrectable
+	}
+
+	for _, position := range positions {
+		frame[position/8] ^= 0x80 >> (position % 8) //nolint: gomnd
+	}
+
+	c.corrected.Add(1)
+
+	return len(positions), nil
+}
+
+// Corrected is the number of repaired frames.
+func (c *ErrorCorrector) Corrected() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	return c.corrected.Load()
+}
+
+// MaxBits is the maximum number of bit errors repaired per frame.
+func (c *ErrorCorrector) MaxBits() int {
+	if c == nil {
+		return 0
+	}
+
+	return c.maxBits
+}
+
+// syndromeTable lists the syndromes of all the error patterns up to maxBits.
+// Ambiguous syndromes are discarded.
+func syndromeTable(bitLength int, maxBits int) map[uint32][]int {
+	table := map[uint32][]int{}
+	ambiguous := map[uint32]struct{}{}
+
+	single := make([]uint32, bitLength)
+
+	for position := downlinkFormatBitSize; position < bitLength; position++ {
+		single[position] = bitSyndrome(bitLength, position)
+	}
+
+	add := func(syndrome uint32, positions ...int) {
+		if _, found := table[syndrome]; found {
+			ambiguous[syndrome] = struct{}{}
+
+			return
+		}
+
+		table[syndrome] = positions
+	}
+
+	if maxBits >= 1 {
+		for position := downlinkFormatBitSize; position < bitLength; position++ {
+			add(single[position], position)
+		}
+	}
+
+	if maxBits >= 2 { //nolint: gomnd
+		for first := downlinkFormatBitSize; first < bitLength; first++ {
+			for second := first + 1; second < bitLength; second++ {
+				add(single[first]^single[second], first, second)
+			}
+		}
+	}
+
+	for syndrome := range ambiguous {
+		delete(table, syndrome)
+	}
+
+	return table
+}
+
+// bitSyndrome is the syndrome of a frame with only one erroneous bit.
+func bitSyndrome(bitLength int, position int) uint32 {
+	dataBitLength := bitLength - parityBitSize
+
+	if position >= dataBitLength {
+		return 1 << (bitLength - 1 - position)
+	}
+
+	frame := make([]byte, bitLength/8) //nolint: gomnd
+
+	frame[position/8] = 0x80 >> (position % 8) //nolint: gomnd
+
+	return ChecksumSquitter(frame[:dataBitLength/8]) //nolint: gomnd
+}
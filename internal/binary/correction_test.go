@@ -0,0 +1,122 @@
+package binary_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCorrector(t *testing.T) {
+	t.Parallel()
+
+	reference, err := hex.DecodeString("8D40621D58C382D690C8AC2863A7")
+	require.NoError(t, err)
+
+	require.Zero(t, binary.Syndrome(reference))
+
+	flip := func(frame []byte, positions ...int) []byte {
+		output := make([]byte, len(frame))
+		copy(output, frame)
+
+		for _, position := range positions {
+			output[position/8] ^= 0x80 >> (position % 8)
+		}
+
+		return output
+	}
+
+	t.Run("no error", func(t *testing.T) {
+		t.Parallel()
+
+		corrector := binary.NewErrorCorrector(1)
+
+		frame := flip(reference)
+
+		fixed, err := corrector.Correct(frame)
+		require.NoError(t, err)
+
+		assert.Zero(t, fixed)
+		assert.Equal(t, reference, frame)
+		assert.Zero(t, corrector.Corrected())
+	})
+
+	for _, position := range []int{5, 42, 87, 88, 111} {
+		bitPosition := position
+
+		t.Run(fmt.Sprintf("one bit error at %d", bitPosition), func(t *testing.T) {
+			t.Parallel()
+
+			corrector := binary.NewErrorCorrector(1)
+
+			frame := flip(reference, bitPosition)
+
+			fixed, err := corrector.Correct(frame)
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, fixed)
+			assert.Equal(t, reference, frame)
+			assert.EqualValues(t, 1, corrector.Corrected())
+		})
+	}
+
+	t.Run("downlink format is never fixed", func(t *testing.T) {
+		t.Parallel()
+
+		corrector := binary.NewErrorCorrector(1)
+
+		_, err := corrector.Correct(flip(reference, 2))
+		require.ErrorIs(t, err, binary.ErrUncorrectable)
+	})
+
+	t.Run("two bits errors", func(t *testing.T) {
+		t.Parallel()
+
+		single := binary.NewErrorCorrector(1)
+
+		_, err := single.Correct(flip(reference, 12, 70))
+		require.ErrorIs(t, err, binary.ErrUncorrectable)
+		assert.Zero(t, single.Corrected())
+
+		double := binary.NewErrorCorrector(2)
+
+		frame := flip(reference, 12, 70)
+
+		fixed, err := double.Correct(frame)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, fixed)
+		assert.Equal(t, reference, frame)
+		assert.EqualValues(t, 1, double.Corrected())
+	})
+
+	t.Run("short frame", func(t *testing.T) {
+		t.Parallel()
+
+		shortReference, err := hex.DecodeString("5d4ca92bf0802f")
+		require.NoError(t, err)
+
+		corrector := binary.NewErrorCorrector(1)
+
+		frame := flip(shortReference, 30)
+
+		fixed, err := corrector.Correct(frame)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, fixed)
+		assert.Equal(t, shortReference, frame)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		var corrector *binary.ErrorCorrector
+
+		_, err := corrector.Correct(flip(reference, 42))
+		require.ErrorIs(t, err, binary.ErrUncorrectable)
+		assert.Zero(t, corrector.Corrected())
+	})
+}
@@ -1,9 +1,24 @@
 // Package compactposition decodes CPR latitudes and longitudes.
 package compactposition
 
-import "math"
+import (
+	"math"
 
-const numberOfLatitudeZones = 15
+	"github.com/landru29/adsb1090/internal/errors"
+)
+
+const (
+	// ErrZoneMismatch is when the odd and even latitudes are not in the same longitude zone.
+	ErrZoneMismatch errors.Error = "odd and even frames in different longitude zones"
+
+	numberOfLatitudeZones = 15
+
+	// cprMax is the number of values of the 17 bits encoded latitudes and longitudes.
+	cprMax = 131072.0
+
+	// surfaceRatio is the ratio between the airborne and the surface zone sizes.
+	surfaceRatio = 4
+)
 
 // longitudeZoneNumber yields the number of longitude zones between 1 and 59.
 func longitudeZoneNumber(latitude float64) int {
@@ -40,7 +55,7 @@ func latitudeZoneSize(odd bool) float64 {
 
 func longitudeZoneCount(odd bool, latitude float64) float64 {
 	if odd {
-		return math.Max(float64(longitudeZoneNumber(latitude-1)), 1)
+		return math.Max(float64(longitudeZoneNumber(latitude)-1), 1)
 	}
 
 	return math.Max(float64(longitudeZoneNumber(latitude)), 1)
@@ -69,13 +84,18 @@ func DecodeLatitude(latCprOdd uint32, latCprEven uint32) (float64, float64) {
 }
 
 // DecodeLongitude decodes both odd and even longitudes.
+// Both latitudes must be in the same longitude zone, or the frames cannot be paired.
 func DecodeLongitude(
 	lngCprOdd uint32, lngCprEven uint32,
 	latitudeOdd float64, latitudeEven float64,
-) (float64, float64) {
+) (float64, float64, error) {
 	lngZoneNumOdd := float64(longitudeZoneNumber(latitudeOdd))
 	lngZoneNumEven := float64(longitudeZoneNumber(latitudeEven))
 
+	if lngZoneNumOdd != lngZoneNumEven {
+		return 0, 0, ErrZoneMismatch
+	}
+
 	longitudeIndex := math.Floor(
 		(float64(lngCprEven)/131072.0)*(lngZoneNumEven-1) -
 			(float64(lngCprOdd)/131072.0)*lngZoneNumOdd +
@@ -87,5 +107,85 @@ func DecodeLongitude(
 	longitudeOdd := longitudeZoneSize(true, latitudeOdd) *
 		(math.Mod(longitudeIndex, longitudeZoneCount(true, latitudeOdd)) + float64(lngCprOdd)/131072.0)
 
-	return longitudeOdd, longitudeEven
+	return longitudeOdd, longitudeEven, nil
+}
+
+// DecodeSurfaceLatitude decodes both odd and even surface latitudes.
+// Surface positions are encoded on 90°: the latitudes are northern, the southern ones are 90° below.
+func DecodeSurfaceLatitude(latCprOdd uint32, latCprEven uint32) (float64, float64) {
+	latitudeZoneIndex := math.Floor(59.0*float64(latCprEven)/cprMax - 60.0*float64(latCprOdd)/cprMax + 0.5)
+
+	latitudeEven := latitudeZoneSize(false) / surfaceRatio *
+		(positiveMod(latitudeZoneIndex, 60.0) + float64(latCprEven)/cprMax) //nolint: gomnd
+	latitudeOdd := latitudeZoneSize(true) / surfaceRatio *
+		(positiveMod(latitudeZoneIndex, 59.0) + float64(latCprOdd)/cprMax) //nolint: gomnd
+
+	return latitudeOdd, latitudeEven
+}
+
+// DecodeSurfaceLongitude decodes both odd and even surface longitudes, with the latitudes of the right hemisphere.
+// Surface positions are encoded on 90°: the longitudes are between 0° and 90°, the other ones are 90° apart.
+func DecodeSurfaceLongitude(
+	lngCprOdd uint32, lngCprEven uint32,
+	latitudeOdd float64, latitudeEven float64,
+) (float64, float64, error) {
+	lngZoneNumOdd := float64(longitudeZoneNumber(latitudeOdd))
+	lngZoneNumEven := float64(longitudeZoneNumber(latitudeEven))
+
+	if lngZoneNumOdd != lngZoneNumEven {
+		return 0, 0, ErrZoneMismatch
+	}
+
+	longitudeIndex := math.Floor(
+		(float64(lngCprEven)/cprMax)*(lngZoneNumEven-1) -
+			(float64(lngCprOdd)/cprMax)*lngZoneNumOdd +
+			0.5,
+	)
+
+	longitudeEven := longitudeZoneSize(false, latitudeEven) / surfaceRatio *
+		(positiveMod(longitudeIndex, longitudeZoneCount(false, latitudeEven)) + float64(lngCprEven)/cprMax)
+	longitudeOdd := longitudeZoneSize(true, latitudeOdd) / surfaceRatio *
+		(positiveMod(longitudeIndex, longitudeZoneCount(true, latitudeOdd)) + float64(lngCprOdd)/cprMax)
+
+	return longitudeOdd, longitudeEven, nil
+}
+
+// DecodeLocalLatitude decodes a single latitude, with a reference latitude less than half a zone away.
+// Surface positions are encoded on 90° instead of 360°.
+func DecodeLocalLatitude(latCpr uint32, odd bool, surface bool, refLatitude float64) float64 {
+	zoneSize := latitudeZoneSize(odd)
+	if surface {
+		zoneSize /= surfaceRatio
+	}
+
+	return localDecode(float64(latCpr)/cprMax, zoneSize, refLatitude)
+}
+
+// DecodeLocalLongitude decodes a single longitude, with a reference longitude less than half a zone away.
+// The latitude is the one decoded from the same frame.
+// Surface positions are encoded on 90° instead of 360°.
+func DecodeLocalLongitude(lngCpr uint32, odd bool, surface bool, latitude float64, refLongitude float64) float64 {
+	zoneSize := longitudeZoneSize(odd, latitude)
+	if surface {
+		zoneSize /= surfaceRatio
+	}
+
+	longitude := localDecode(float64(lngCpr)/cprMax, zoneSize, refLongitude)
+	if longitude > 180 { //nolint: gomnd
+		longitude -= 360.0
+	}
+
+	return longitude
+}
+
+// localDecode chooses the zone containing the reference, and places the encoded value in it.
+func localDecode(cpr float64, zoneSize float64, reference float64) float64 {
+	zoneIndex := math.Floor(reference/zoneSize) +
+		math.Floor(0.5+positiveMod(reference, zoneSize)/zoneSize-cpr) //nolint: gomnd
+
+	return zoneSize * (zoneIndex + cpr)
+}
+
+func positiveMod(value float64, modulo float64) float64 {
+	return value - modulo*math.Floor(value/modulo)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/landru29/adsb1090/internal/compactposition"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDecodeLatitude(t *testing.T) {
@@ -22,7 +23,71 @@ func TestDecodeLongitude(t *testing.T) {
 	assert.InDelta(t, 52.26578017412606, latOdd, 0.0000000000001)
 	assert.InDelta(t, 52.25720214843750, latEven, 0.0000000000001)
 
-	lngOdd, lngEven := compactposition.DecodeLongitude(50194, 51372, latOdd, latEven)
-	assert.InDelta(t, 3.72599833720439, lngOdd, 0.0000000000001)
+	lngOdd, lngEven, err := compactposition.DecodeLongitude(50194, 51372, latOdd, latEven)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.93891252790178, lngOdd, 0.0000000000001)
 	assert.InDelta(t, 3.91937255859375, lngEven, 0.0000000000001)
 }
+
+func TestDecodeLongitudeZoneMismatch(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := compactposition.DecodeLongitude(50194, 51372, 52.2, 59.5)
+	require.ErrorIs(t, err, compactposition.ErrZoneMismatch)
+}
+
+func TestDecodeSurface(t *testing.T) {
+	t.Parallel()
+
+	latOdd, latEven := compactposition.DecodeSurfaceLatitude(39199, 115609)
+	assert.InDelta(t, 52.32061, latOdd, 0.00001)
+	assert.InDelta(t, 52.32304, latEven, 0.00001)
+
+	lngOdd, lngEven, err := compactposition.DecodeSurfaceLongitude(110269, 116941, latOdd, latEven)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.73473, lngOdd, 0.00001)
+	assert.InDelta(t, 4.73047, lngEven, 0.00001)
+}
+
+func TestDecodeLocal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("airborne even", func(t *testing.T) {
+		t.Parallel()
+
+		lat := compactposition.DecodeLocalLatitude(93000, false, false, 52.258)
+		assert.InDelta(t, 52.25720214843750, lat, 0.0000000000001)
+
+		lng := compactposition.DecodeLocalLongitude(51372, false, false, lat, 3.918)
+		assert.InDelta(t, 3.91937255859375, lng, 0.0000000000001)
+	})
+
+	t.Run("airborne odd", func(t *testing.T) {
+		t.Parallel()
+
+		lat := compactposition.DecodeLocalLatitude(74158, true, false, 52.258)
+		assert.InDelta(t, 52.26578017412606, lat, 0.0000000000001)
+
+		lng := compactposition.DecodeLocalLongitude(50194, true, false, lat, 3.918)
+		assert.InDelta(t, 3.93891252790178, lng, 0.0000000000001)
+	})
+
+	t.Run("surface", func(t *testing.T) {
+		t.Parallel()
+
+		lat := compactposition.DecodeLocalLatitude(39195, true, true, 51.990)
+		assert.InDelta(t, 52.32056, lat, 0.00001)
+
+		lng := compactposition.DecodeLocalLongitude(110320, true, true, lat, 4.375)
+		assert.InDelta(t, 4.73574, lng, 0.00001)
+	})
+
+	t.Run("western hemisphere", func(t *testing.T) {
+		t.Parallel()
+
+		lat := compactposition.DecodeLocalLatitude(93000, false, false, 52.258)
+
+		lng := compactposition.DecodeLocalLongitude(51372, false, false, lat, -6.082)
+		assert.InDelta(t, 3.91937255859375-10, lng, 0.0000000000001)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/landru29/adsb1090/internal/model"
 	"github.com/landru29/adsb1090/internal/serialize/nmea"
 	"github.com/landru29/adsb1090/internal/transport/net"
 	"github.com/mcuadros/go-defaults"
@@ -21,6 +22,11 @@ const (
 	defaultNMEAmid                        = 226
 	defaultFrequency                      = 1090000000
 	defaultDatabaseLifetime time.Duration = time.Minute
+	defaultCRCFixBits                     = 1
+	defaultBeastAddr                      = "0.0.0.0:30005"
+	defaultAVRAddr                        = "0.0.0.0:30002"
+	defaultMaxRange                       = 300
+	defaultStatsInterval    time.Duration = time.Minute
 )
 
 // Config is the application configuration.
@@ -38,11 +44,23 @@ type Config struct {
 	UDPConf                  net.ProtocolConfig `default:""                                               json:"udpConf"                  yaml:"udpConf"`                  //nolint: lll
 	TCPConf                  net.ProtocolConfig `default:""                                               json:"tcpConf"                  yaml:"tcpConf"`                  //nolint: lll
 	HTTPConf                 HTTPConfig         `default:""                                               json:"httpConf"                 yaml:"httpConf"`                 //nolint: lll
+	HTTPMetrics              bool               `default:"false"                                          json:"httpMetrics"              yaml:"httpMetrics"`              //nolint: lll
 	TransportScreen          string             `default:""                                               json:"transportScreen"          yaml:"transportScreen"`          //nolint: lll
 	NmeaVessel               Vessel             `default:""                                               json:"nmeaVessel"               yaml:"nmeaVessel"`               //nolint: lll
 	NmeaMid                  uint16             `default:"226"                                            json:"nmeaMid"                  yaml:"nmeaMid"`                  //nolint: lll
 	TransportFile            string             `default:""                                               json:"transportFile"            yaml:"transportFile"`            //nolint: lll
 	AircraftDatabaseFilename string             `default:"aircrafts.json.gz"                              json:"aircraftDatabaseFilename" yaml:"aircraftDatabaseFilename"` //nolint: lll
+	CRCFixBits               uint8              `default:"1"                                              json:"crcFixBits"               yaml:"crcFixBits"`               //nolint: lll
+	StrictReference          bool               `default:"false"                                          json:"strictReference"          yaml:"strictReference"`          //nolint: lll
+	BeastInput               string             `default:""                                               json:"beastInput"               yaml:"beastInput"`               //nolint: lll
+	BeastConf                net.ProtocolConfig `default:""                                               json:"beastConf"                yaml:"beastConf"`                //nolint: lll
+	AVRInput                 string             `default:""                                               json:"avrInput"                 yaml:"avrInput"`                 //nolint: lll
+	AVRConf                  net.ProtocolConfig `default:""                                               json:"avrConf"                  yaml:"avrConf"`                  //nolint: lll
+	Receiver                 ReceiverConfig     `default:""                                               json:"receiver"                 yaml:"receiver"`                 //nolint: lll
+	MaxRange                 float64            `default:"300"                                            json:"maxRange"                 yaml:"maxRange"`                 //nolint: lll
+	Staleness                StalenessConfig    `default:""                                               json:"staleness"                yaml:"staleness"`                //nolint: lll
+	OmitStale                bool               `default:"false"                                          json:"omitStale"                yaml:"omitStale"`                //nolint: lll
+	StatsInterval            time.Duration      `default:"1m"                                             json:"statsInterval"            yaml:"statsInterval"`            //nolint: lll
 }
 
 func newConfig(flags *pflag.FlagSet) *Config { //nolint: funlen
@@ -50,7 +68,11 @@ func newConfig(flags *pflag.FlagSet) *Config { //nolint: funlen
 		DatabaseLifetime: defaultDatabaseLifetime,
 		UDPConf:          net.NewProtocol("udp"),
 		TCPConf:          net.NewProtocol("tcp"),
+		BeastConf:        net.NewProtocol("tcp").WithDefaultAddr(defaultBeastAddr),
+		AVRConf:          net.NewProtocol("tcp").WithDefaultAddr(defaultAVRAddr),
 		NmeaVessel:       nmea.VesselTypeAircraft,
+		Staleness:        newStalenessConfig(),
+		StatsInterval:    defaultStatsInterval,
 	}
 	if flags != nil {
 		flags.StringVarP(
@@ -130,6 +152,14 @@ func newConfig(flags *pflag.FlagSet) *Config { //nolint: funlen
 			"transmit data over http (syntax: 'host:port/path'; ie: --http 0.0.0.0:8080/api)",
 		)
 
+		flags.BoolVarP(
+			&output.HTTPMetrics,
+			"http-metrics",
+			"",
+			false,
+			"with --http, serve the Prometheus metrics on /metrics",
+		)
+
 		flags.StringVarP(
 			&output.TransportScreen,
 			"screen",
@@ -168,6 +198,90 @@ func newConfig(flags *pflag.FlagSet) *Config { //nolint: funlen
 			"",
 			"format to display output on a file; ie --out-file nmea@/tmp/foo.txt",
 		)
+
+		flags.Uint8VarP(
+			&output.CRCFixBits,
+			"crc-fix-bits",
+			"",
+			defaultCRCFixBits,
+			"number of bit errors to fix in DF11/17/18 frames (0: disabled, max: 2)",
+		)
+
+		flags.BoolVarP(
+			&output.StrictReference,
+			"strict-reference",
+			"",
+			false,
+			"only track aircraft found in the aircraft database",
+		)
+
+		flags.StringVarP(
+			&output.BeastInput,
+			"beast-input",
+			"",
+			"",
+			"read Beast frames over tcp instead of the device (syntax: 'direction>host:port'; ie: --beast-input dial>192.168.1.10:30005)",
+		)
+
+		flags.VarP(
+			&output.BeastConf,
+			"beast-output",
+			"",
+			"transmit raw frames in Beast format over tcp (syntax: 'direction>host:port'; ie: --beast-output bind>0.0.0.0:30005)",
+		)
+
+		flags.StringVarP(
+			&output.AVRInput,
+			"avr-input",
+			"",
+			"",
+			"read AVR frames instead of the device, from a file, stdin ('-') or tcp (syntax: 'direction>host:port'; ie: --avr-input dial>192.168.1.10:30002)", //nolint: lll
+		)
+
+		flags.VarP(
+			&output.AVRConf,
+			"avr-output",
+			"",
+			"transmit raw frames in AVR format over tcp (syntax: 'direction>host:port'; ie: --avr-output bind>0.0.0.0:30002)",
+		)
+
+		flags.VarP(
+			&output.Receiver,
+			"receiver",
+			"",
+			"receiver location, to decode the positions from a single frame (syntax: 'latitude,longitude'; ie: --receiver 48.8566,2.3522)", //nolint: lll
+		)
+
+		flags.Float64VarP(
+			&output.MaxRange,
+			"max-range",
+			"",
+			defaultMaxRange,
+			"maximum distance in km between the receiver and the decoded positions (0: no limit)",
+		)
+
+		flags.VarP(
+			&output.Staleness,
+			"staleness",
+			"",
+			"maximum age of the aircraft fields in the outputs (syntax: 'field=duration,...'; ie: --staleness position=30s,squawk=0s)", //nolint: lll
+		)
+
+		flags.BoolVarP(
+			&output.OmitStale,
+			"omit-stale",
+			"",
+			false,
+			"remove the stale fields from the outputs instead of flagging them",
+		)
+
+		flags.DurationVarP(
+			&output.StatsInterval,
+			"stats-interval",
+			"",
+			defaultStatsInterval,
+			"period of the statistics in the logs (0: disabled)",
+		)
 	}
 
 	defaults.SetDefaults(output)
@@ -222,6 +336,21 @@ func (s Config) Path() string {
 	return filepath.Join(s.basePath, settingsFilename)
 }
 
+// ReceiverLocation is the receiver used to decode the positions; nil when the location is not set.
+func (s Config) ReceiverLocation() *model.Receiver {
+	if !s.Receiver.Enabled {
+		return nil
+	}
+
+	return &model.Receiver{
+		Position: model.Position{
+			Latitude:  s.Receiver.Latitude,
+			Longitude: s.Receiver.Longitude,
+		},
+		MaxRange: s.MaxRange * 1000, //nolint: gomnd
+	}
+}
+
 // AircraftDatabaseFile is the path of the aircraft database.
 func (s Config) AircraftDatabaseFile() string {
 	return filepath.Join(s.basePath, s.AircraftDatabaseFilename)
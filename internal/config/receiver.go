@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/landru29/adsb1090/internal/errors"
+)
+
+const (
+	// ErrInvalidReceiver is when the receiver location cannot be parsed.
+	ErrInvalidReceiver errors.Error = "invalid receiver location (syntax: 'latitude,longitude')"
+
+	maxLatitude  = 90
+	maxLongitude = 180
+)
+
+// ReceiverConfig is the location of the receiver.
+type ReceiverConfig struct {
+	Latitude  float64 `json:"lat"     yaml:"lat"`
+	Longitude float64 `json:"lng"     yaml:"lng"`
+	Enabled   bool    `json:"enabled" yaml:"enabled"`
+}
+
+// String implements the pflag.Value interface.
+func (r *ReceiverConfig) String() string {
+	if !r.Enabled {
+		return ""
+	}
+
+	return fmt.Sprintf("%f,%f", r.Latitude, r.Longitude)
+}
+
+// Set implements the pflag.Value interface.
+func (r *ReceiverConfig) Set(str string) error {
+	splitter := strings.Split(str, ",")
+	if len(splitter) != 2 { //nolint: gomnd
+		return ErrInvalidReceiver
+	}
+
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(splitter[0]), 64)
+	if err != nil || latitude < -maxLatitude || latitude > maxLatitude {
+		return ErrInvalidReceiver
+	}
+
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(splitter[1]), 64)
+	if err != nil || longitude < -maxLongitude || longitude > maxLongitude {
+		return ErrInvalidReceiver
+	}
+
+	*r = ReceiverConfig{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Enabled:   true,
+	}
+
+	return nil
+}
+
+// Type implements the pflag.Value interface.
+func (r *ReceiverConfig) Type() string {
+	return "receiver location"
+}
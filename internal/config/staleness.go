@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/errors"
+	"github.com/landru29/adsb1090/internal/model"
+)
+
+const (
+	// ErrInvalidStaleness is when the staleness thresholds cannot be parsed.
+	ErrInvalidStaleness errors.Error = "invalid staleness (syntax: 'field=duration,...'; " +
+		"fields: position, altitude, velocity, identification, squawk, status)"
+)
+
+// StalenessConfig is the maximum age of the aircraft fields in the outputs.
+type StalenessConfig struct {
+	Position          time.Duration `json:"position"          yaml:"position"`
+	Altitude          time.Duration `json:"altitude"          yaml:"altitude"`
+	Velocity          time.Duration `json:"velocity"          yaml:"velocity"`
+	Identification    time.Duration `json:"identification"    yaml:"identification"`
+	Squawk            time.Duration `json:"squawk"            yaml:"squawk"`
+	OperationalStatus time.Duration `json:"operationalStatus" yaml:"operationalStatus"`
+}
+
+func newStalenessConfig() StalenessConfig {
+	staleness := model.DefaultStaleness()
+
+	return StalenessConfig{
+		Position:          staleness.Position,
+		Altitude:          staleness.Altitude,
+		Velocity:          staleness.Velocity,
+		Identification:    staleness.Identification,
+		Squawk:            staleness.Squawk,
+		OperationalStatus: staleness.OperationalStatus,
+	}
+}
+
+func (s *StalenessConfig) fields() map[string]*time.Duration {
+	return map[string]*time.Duration{
+		"position":       &s.Position,
+		"altitude":       &s.Altitude,
+		"velocity":       &s.Velocity,
+		"identification": &s.Identification,
+		"squawk":         &s.Squawk,
+		"status":         &s.OperationalStatus,
+	}
+}
+
+// String implements the pflag.Value interface.
+func (s *StalenessConfig) String() string {
+	return fmt.Sprintf(
+		"position=%s,altitude=%s,velocity=%s,identification=%s,squawk=%s,status=%s",
+		s.Position,
+		s.Altitude,
+		s.Velocity,
+		s.Identification,
+		s.Squawk,
+		s.OperationalStatus,
+	)
+}
+
+// Set implements the pflag.Value interface.
+// Only the specified fields are changed.
+func (s *StalenessConfig) Set(str string) error {
+	fields := s.fields()
+
+	for _, assignment := range strings.Split(str, ",") {
+		splitter := strings.Split(assignment, "=")
+		if len(splitter) != 2 { //nolint: gomnd
+			return ErrInvalidStaleness
+		}
+
+		field, found := fields[strings.TrimSpace(splitter[0])]
+		if !found {
+			return ErrInvalidStaleness
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(splitter[1]))
+		if err != nil || duration < 0 {
+			return ErrInvalidStaleness
+		}
+
+		*field = duration
+	}
+
+	return nil
+}
+
+// Type implements the pflag.Value interface.
+func (s *StalenessConfig) Type() string {
+	return "staleness"
+}
+
+// Model is the staleness used by the serializers.
+func (s StalenessConfig) Model(omit bool) model.Staleness {
+	return model.Staleness{
+		Position:          s.Position,
+		Altitude:          s.Altitude,
+		Velocity:          s.Velocity,
+		Identification:    s.Identification,
+		Squawk:            s.Squawk,
+		OperationalStatus: s.OperationalStatus,
+		Omit:              omit,
+	}
+}
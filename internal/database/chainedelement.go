@@ -10,6 +10,12 @@ type ChainedElement[T any] struct {
 	previous *ChainedElement[T]
 }
 
+// DatedData is the data of an element, with its storage date.
+type DatedData[T any] struct {
+	Date time.Time
+	Data T
+}
+
 // Last is the last Element of the chain.
 func (e *ChainedElement[T]) Last() *ChainedElement[T] {
 	output := e
@@ -53,9 +53,23 @@ func TestChainedStorage(t *testing.T) {
 		storage.Add("24", 42.0)
 
 		assert.Len(t, storage.Keys(), 2)
+		assert.Equal(t, 3, storage.Len())
 
-		assert.Len(t, storage.Elements("42"), 2)
+		assert.Equal(t, []float64{42.0, 24.0}, storage.Elements("42"))
 		assert.Len(t, storage.Elements("24"), 1)
+
+		dated := storage.DatedElements("42")
+		require.Len(t, dated, 2)
+		assert.Equal(t, 24.0, dated[1].Data) //nolint: testifylint
+		assert.False(t, dated[1].Date.Before(dated[0].Date))
+
+		walked := []float64{}
+		storage.ReverseWalk("42", func(element database.DatedData[float64]) bool {
+			walked = append(walked, element.Data)
+
+			return true
+		})
+		assert.Equal(t, []float64{24.0, 42.0}, walked)
 	})
 
 	t.Run("add elements", func(t *testing.T) {
@@ -12,6 +12,7 @@ import (
 // ChainedStorage is the database storage.
 type ChainedStorage[K comparable, T any] struct {
 	data       map[K]*ChainedElement[T]
+	tails      map[K]*ChainedElement[T]
 	timer      *time.Ticker
 	mutex      sync.Mutex
 	lifetime   time.Duration
@@ -28,6 +29,7 @@ func NewChainedStorage[K comparable, T any]( //nolint: dupl
 ) *ChainedStorage[K, T] {
 	out := &ChainedStorage[K, T]{
 		data:       map[K]*ChainedElement[T]{},
+		tails:      map[K]*ChainedElement[T]{},
 		lifetime:   defaultLifetime,
 		cleanCycle: defaultCleanCycle,
 		stop:       make(chan struct{}),
@@ -82,8 +84,8 @@ func (s *ChainedStorage[K, T]) Clean() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	for key, chain := range s.data {
-		current := chain.Last()
+	for key := range s.data {
+		current := s.tails[key]
 
 		for current != nil && !current.expired(s.lifetime) {
 			current = current.previous
@@ -101,6 +103,7 @@ func (s *ChainedStorage[K, T]) Clean() {
 		}
 
 		delete(s.data, key)
+		delete(s.tails, key)
 	}
 }
 
@@ -109,15 +112,16 @@ func (s *ChainedStorage[K, T]) Add(key K, element T) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	chain, found := s.data[key]
+	last, found := s.tails[key]
 	if found {
-		last := chain.Last()
 		last.next = &ChainedElement[T]{
 			previous: last,
 			data:     element,
 			date:     time.Now(),
 		}
 
+		s.tails[key] = last.next
+
 		return
 	}
 
@@ -125,6 +129,8 @@ func (s *ChainedStorage[K, T]) Add(key K, element T) {
 		data: element,
 		date: time.Now(),
 	}
+
+	s.tails[key] = s.data[key]
 }
 
 // Keys list the available keys.
@@ -145,24 +151,71 @@ func (s *ChainedStorage[K, T]) Keys() []K {
 	return out
 }
 
+// Len is the number of stored elements, in all the chains.
+func (s *ChainedStorage[K, T]) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	count := 0
+
+	for _, current := range s.data {
+		for ; current != nil; current = current.next {
+			count++
+		}
+	}
+
+	return count
+}
+
 // Elements is the list of data for a specified key.
 func (s *ChainedStorage[K, T]) Elements(key K) []T {
+	elements := s.DatedElements(key)
+	if elements == nil {
+		return nil
+	}
+
+	out := make([]T, len(elements))
+
+	for idx, element := range elements {
+		out[idx] = element.Data
+	}
+
+	return out
+}
+
+// DatedElements is the list of data for a specified key, with their storage dates.
+func (s *ChainedStorage[K, T]) DatedElements(key K) []DatedData[T] {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	if elements, found := s.data[key]; found {
-		out := []T{elements.data}
-
-		for elements.next != nil {
-			out = append(out, elements.data)
+	elements, found := s.data[key]
+	if !found {
+		return nil
+	}
 
-			elements = elements.next
-		}
+	out := []DatedData[T]{}
 
-		return out
+	for ; elements != nil; elements = elements.next {
+		out = append(out, DatedData[T]{
+			Date: elements.date,
+			Data: elements.data,
+		})
 	}
 
-	return nil
+	return out
+}
+
+// ReverseWalk calls the function on the elements of a specified key, from the most recent one,
+// until it returns false.
+func (s *ChainedStorage[K, T]) ReverseWalk(key K, walk func(DatedData[T]) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for element := s.tails[key]; element != nil; element = element.previous {
+		if !walk(DatedData[T]{Date: element.date, Data: element.data}) {
+			return
+		}
+	}
 }
 
 // Close implements the io.Closer interface.
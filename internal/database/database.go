@@ -9,3 +9,8 @@ const (
 	defaultLifetime   time.Duration = time.Minute * 5
 	defaultCleanCycle time.Duration = defaultLifetime / 3
 )
+
+// Sizer is a storage with a size.
+type Sizer interface {
+	Len() int
+}
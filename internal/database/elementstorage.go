@@ -118,6 +118,14 @@ func (s *ElementStorage[K, T]) Keys() []K {
 	return out
 }
 
+// Len is the number of stored elements.
+func (s *ElementStorage[K, T]) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.data)
+}
+
 // Element is the list of data for a specified key.
 func (s *ElementStorage[K, T]) Element(key K) *T {
 	s.mutex.Lock()
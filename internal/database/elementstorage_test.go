@@ -53,6 +53,7 @@ func TestElementStorage(t *testing.T) {
 		storage.Add("24", 42.0)
 
 		assert.Len(t, storage.Keys(), 2)
+		assert.Equal(t, 2, storage.Len())
 
 		time.Sleep(time.Millisecond * 500)
 
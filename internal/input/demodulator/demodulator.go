@@ -0,0 +1,238 @@
+// Package demodulator is the pure Go Mode S demodulator.
+package demodulator
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/binary"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/processor"
+	"github.com/landru29/adsb1090/internal/stats"
+)
+
+const (
+	modeSLongMessageBits  = 112
+	modeSShortMessageBits = 56
+
+	// I: 1 byte, Q: 1 byte.
+	iqSize = 2
+
+	// 10 => 1, 01 => 0.
+	magnitudeEncodedBitSize = 2
+
+	// Number of all magnitudes for a long message.
+	magnitudeLongMessageSize = modeSLongMessageBits * magnitudeEncodedBitSize
+
+	// Size of the preambule in peaks.
+	preambuleBitSize = 16
+
+	magnitudeTableSide = 129
+	magnitudeScale     = 360
+	iqMiddle           = 127
+
+	// The samples are taken at 2 MHz: each sample is 6 ticks of the 12 MHz counter.
+	timestampPerSample = 6
+)
+
+var (
+	magnitudes     []uint16  //nolint: gochecknoglobals
+	magnitudesOnce sync.Once //nolint: gochecknoglobals
+)
+
+// Configurator is the Demodulator configurator.
+type Configurator func(*Demodulator)
+
+// Demodulator extracts Mode S frames from raw 8-bit I/Q samples.
+type Demodulator struct {
+	processors     []processor.Processer
+	remaining      []uint16
+	errorCorrector *binary.ErrorCorrector
+	stats          *stats.Stats
+	sampleIndex    uint64 /* Index of the first sample of the remaining data, since the start. */
+}
+
+// New creates a new demodulator.
+func New(processors []processor.Processer, opts ...Configurator) *Demodulator {
+	magnitudesOnce.Do(initMagnitudes)
+
+	output := &Demodulator{
+		processors: processors,
+		remaining:  make([]uint16, 0, magnitudeLongMessageSize+preambuleBitSize),
+	}
+
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	return output
+}
+
+// WithErrorCorrector repairs the frames before sending them to the processors.
+func WithErrorCorrector(corrector *binary.ErrorCorrector) Configurator {
+	return func(d *Demodulator) {
+		d.errorCorrector = corrector
+	}
+}
+
+// WithStats counts the detected preambles.
+func WithStats(statistics *stats.Stats) Configurator {
+	return func(d *Demodulator) {
+		d.stats = statistics
+	}
+}
+
+// initMagnitudes computes the magnitude of each I/Q pair.
+func initMagnitudes() {
+	magnitudes = make([]uint16, magnitudeTableSide*magnitudeTableSide)
+
+	for i := 0; i < magnitudeTableSide; i++ {
+		for q := 0; q < magnitudeTableSide; q++ {
+			magnitudes[i*magnitudeTableSide+q] = uint16(math.Round(math.Sqrt(float64(i*i+q*q)) * magnitudeScale))
+		}
+	}
+}
+
+// Write implements the io.Writer interface.
+// The data must be a set of interleaved I/Q bytes.
+func (d *Demodulator) Write(data []byte) (int, error) {
+	d.Process(data)
+
+	return len(data), nil
+}
+
+// Process demodulates a buffer of I/Q bytes and sends the frames to the processors.
+func (d *Demodulator) Process(data []byte) {
+	magnitudeBuffer := d.computeMagnitudes(data)
+
+	magnitudeCount := len(magnitudeBuffer)
+	limitProcess := magnitudeCount - magnitudeLongMessageSize - preambuleBitSize
+
+	idx := 0
+
+	for ; idx < limitProcess; idx++ {
+		if !isPreambule(magnitudeBuffer[idx:]) {
+			continue
+		}
+
+		d.stats.Preamble()
+
+		message := decodeMessage(magnitudeBuffer[idx+preambuleBitSize:])
+
+		frame := model.Frame{
+			ModeS:      message,
+			Timestamp:  (d.sampleIndex + uint64(idx)) * timestampPerSample,
+			ReceivedAt: time.Now(),
+			RSSI:       model.RSSIFromMagnitude(preambuleLevel(magnitudeBuffer[idx:])),
+		}
+
+		if d.dispatch(frame) {
+			// jump over the message.
+			idx += preambuleBitSize + len(message)*8*magnitudeEncodedBitSize
+		}
+	}
+
+	if idx > magnitudeCount {
+		idx = magnitudeCount
+	}
+
+	// Keep the remaining data for the next buffer.
+	d.remaining = append(d.remaining[:0], magnitudeBuffer[idx:]...)
+	d.sampleIndex += uint64(idx)
+}
+
+func (d *Demodulator) dispatch(frame model.Frame) bool {
+	if d.errorCorrector != nil {
+		frame.CorrectedBits, _ = frame.ModeS.Fix(d.errorCorrector)
+	}
+
+	for _, proc := range d.processors {
+		if err := proc.Process(frame); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *Demodulator) computeMagnitudes(data []byte) []uint16 {
+	sampleCount := len(data) / iqSize
+
+	output := make([]uint16, len(d.remaining), len(d.remaining)+sampleCount)
+	copy(output, d.remaining)
+
+	for idx := 0; idx < sampleCount; idx++ {
+		output = append(output, magnitudes[centered(data[idx*iqSize])*magnitudeTableSide+centered(data[idx*iqSize+1])])
+	}
+
+	return output
+}
+
+func centered(value byte) int {
+	if value > iqMiddle {
+		return int(value) - iqMiddle
+	}
+
+	return iqMiddle - int(value)
+}
+
+// Signature detection:
+//
+//	|   |         |   |
+//	|   |         |   |
+//	|   |         |   |
+//	|   |         |   |
+//	| | | | | | | | | | | | | | | |
+//	0 1 2 3 4 5 6 7 8 9 10
+func isPreambule(mag []uint16) bool { //nolint: cyclop
+	if mag[0] <= mag[1] ||
+		mag[1] >= mag[2] ||
+		mag[2] <= mag[3] ||
+		mag[2] <= mag[4] ||
+		mag[2] <= mag[5] ||
+		mag[2] <= mag[6] ||
+		mag[6] >= mag[7] ||
+		mag[7] <= mag[8] ||
+		mag[8] >= mag[9] {
+		return false
+	}
+
+	for idx := 10; idx < preambuleBitSize; idx++ {
+		if mag[9] <= mag[idx] {
+			return false
+		}
+	}
+
+	meanHigh := preambuleLevel(mag)
+
+	return mag[0]/meanHigh <= 2 && mag[2]/meanHigh <= 2 && mag[7]/meanHigh <= 2 && mag[9]/meanHigh <= 2
+}
+
+// preambuleLevel is the mean magnitude of the preambule peaks.
+func preambuleLevel(mag []uint16) uint16 {
+	return uint16((uint32(mag[0]) + uint32(mag[2]) + uint32(mag[7]) + uint32(mag[9])) / 4) //nolint: gomnd
+}
+
+// +----------+--------------+-----------+
+// |  DF (5)  | (83) or (27) |  PI (24)  |
+// +----------+--------------+-----------+.
+func decodeMessage(mag []uint16) []byte {
+	messageLengthBit := modeSShortMessageBits
+
+	// If the first bit of DF is 1, this means the message will be long 112 bits (extended squitter),
+	// otherwise, the message will be short 56 bits (normal squitter).
+	if mag[0] > mag[1] {
+		messageLengthBit = modeSLongMessageBits
+	}
+
+	message := make([]byte, messageLengthBit/8) //nolint: gomnd
+
+	for index := 0; index < messageLengthBit; index++ {
+		if mag[index*2] > mag[index*2+1] {
+			message[index/8] |= 1 << (7 - index%8) //nolint: gomnd
+		}
+	}
+
+	return message
+}
@@ -0,0 +1,126 @@
+package demodulator_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/binary"
+	"github.com/landru29/adsb1090/internal/input/demodulator"
+	"github.com/landru29/adsb1090/internal/mocks"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func referenceFrames(t *testing.T) []string {
+	t.Helper()
+
+	file, err := os.Open("../../../testdata/modes1.txt")
+	require.NoError(t, err)
+
+	defer func(closer io.Closer) {
+		require.NoError(t, closer.Close())
+	}(file)
+
+	output := []string{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		output = append(output, strings.Trim(scanner.Text(), "*;"))
+	}
+
+	return output
+}
+
+func TestDemodulator(t *testing.T) {
+	t.Parallel()
+
+	for _, chunkSize := range []int64{1024, 4096, 262144} {
+		size := chunkSize
+
+		t.Run(fmt.Sprintf("chunks of %d bytes", size), func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			frames := []string{}
+			timestamp := uint64(0)
+
+			mockProcessor := mocks.NewMockProcesser(ctrl)
+			mockProcessor.EXPECT().Process(gomock.Any()).DoAndReturn(func(frame model.Frame) error {
+				frames = append(frames, frame.ModeS.String())
+
+				assert.Greater(t, frame.Timestamp, timestamp)
+				assert.Negative(t, frame.RSSI)
+				assert.False(t, frame.ReceivedAt.IsZero())
+
+				timestamp = frame.Timestamp
+
+				return nil
+			}).AnyTimes()
+
+			file, err := os.Open("../../../testdata/modes1.bin")
+			require.NoError(t, err)
+
+			defer func(closer io.Closer) {
+				require.NoError(t, closer.Close())
+			}(file)
+
+			demod := demodulator.New([]processor.Processer{mockProcessor})
+
+			for {
+				_, err := io.CopyN(demod, file, size)
+				if err != nil {
+					require.ErrorIs(t, err, io.EOF)
+
+					break
+				}
+			}
+
+			assert.Equal(t, referenceFrames(t), frames)
+		})
+	}
+}
+
+func TestDemodulatorErrorCorrection(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	valid := 0
+	corrected := 0
+
+	mockProcessor := mocks.NewMockProcesser(ctrl)
+	mockProcessor.EXPECT().Process(gomock.Any()).DoAndReturn(func(frame model.Frame) error {
+		if err := frame.ModeS.CheckSum(); err != nil {
+			return err
+		}
+
+		valid++
+		corrected += frame.CorrectedBits
+
+		return nil
+	}).AnyTimes()
+
+	file, err := os.Open("../../../testdata/modes1.bin")
+	require.NoError(t, err)
+
+	defer func(closer io.Closer) {
+		require.NoError(t, closer.Close())
+	}(file)
+
+	corrector := binary.NewErrorCorrector(1)
+
+	_, err = io.Copy(demodulator.New([]processor.Processer{mockProcessor}, demodulator.WithErrorCorrector(corrector)), file)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 3, corrector.Corrected())
+	assert.Equal(t, 467, valid)
+	assert.Equal(t, 3, corrected)
+}
@@ -6,13 +6,17 @@ import (
 	"os"
 	"syscall"
 
+	"github.com/landru29/adsb1090/internal/binary"
+	"github.com/landru29/adsb1090/internal/input/demodulator"
 	"github.com/landru29/adsb1090/internal/processor"
+	"github.com/landru29/adsb1090/internal/stats"
 )
 
 // File is the data file source.
 type File struct {
-	filename string
-	loop     bool
+	filename       string
+	loop           bool
+	errorCorrector *binary.ErrorCorrector
 }
 
 // FileConfigurator is the Source configurator.
@@ -38,6 +42,13 @@ func WithLoop() FileConfigurator {
 	}
 }
 
+// WithErrorCorrector repairs the frames before processing them.
+func WithErrorCorrector(corrector *binary.ErrorCorrector) FileConfigurator {
+	return func(s *File) {
+		s.errorCorrector = corrector
+	}
+}
+
 // Start implements the input.Starter interface.
 func (s *File) Start(ctx context.Context, processors ...processor.Processer) error {
 	if s.loop {
@@ -70,5 +81,14 @@ func (s *File) start(ctx context.Context, processors ...processor.Processer) err
 		_ = closer.Close()
 	}(fileDescriptor)
 
-	return NewReader(fileDescriptor).Start(ctx, processors...)
+	opts := []demodulator.Configurator{}
+	if s.errorCorrector != nil {
+		opts = append(opts, demodulator.WithErrorCorrector(s.errorCorrector))
+	}
+
+	if statistics, found := stats.FromContext(ctx); found {
+		opts = append(opts, demodulator.WithStats(statistics))
+	}
+
+	return NewReader(fileDescriptor, opts...).Start(ctx, processors...)
 }
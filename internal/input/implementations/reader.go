@@ -5,29 +5,27 @@ import (
 	"errors"
 	"io"
 
-	localcontext "github.com/landru29/adsb1090/internal/input/context"
+	"github.com/landru29/adsb1090/internal/input/demodulator"
 	"github.com/landru29/adsb1090/internal/processor"
 )
 
 // Reader is device reader.
 type Reader struct {
-	reader io.Reader
+	reader          io.Reader
+	demodulatorOpts []demodulator.Configurator
 }
 
 // NewReader creates a new device reader.
-func NewReader(rd io.Reader) *Reader {
+func NewReader(rd io.Reader, opts ...demodulator.Configurator) *Reader {
 	return &Reader{
-		reader: rd,
+		reader:          rd,
+		demodulatorOpts: opts,
 	}
 }
 
 // Start implements the input.Starter interface.
-func (r *Reader) Start(ctx context.Context, processors ...processor.Processer) error {
-	cContext := localcontext.New(ctx, processors)
-
-	defer func() {
-		localcontext.DisposeContext(cContext.Key)
-	}()
+func (r *Reader) Start(_ context.Context, processors ...processor.Processer) error {
+	demod := demodulator.New(processors, r.demodulatorOpts...)
 
 	for {
 		data := make([]byte, 1024) //nolint: gomnd
@@ -41,6 +39,6 @@ func (r *Reader) Start(ctx context.Context, processors ...processor.Processer) e
 			return err
 		}
 
-		processRaw(data[:cnt], cContext.Ccontext)
+		demod.Process(data[:cnt])
 	}
 }
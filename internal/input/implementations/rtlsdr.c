@@ -1,4 +1,7 @@
+//go:build !nortlsdr
+
 #include "rtlsdr.h"
+#include <inttypes.h>
 #include <math.h>
 #include <malloc.h>
 #include <string.h>
@@ -10,7 +13,7 @@
 
 
 uint16_t magnitude[129*129];
-int globalIndex = 0;
+uint64_t globalIndex = 0; // sample index since the start; 64 bits, not to overflow.
 uint32_t *icaoAddressList = 0;
 uint64_t icaoAddressListLength = 0;
 int _debug=0;
@@ -145,7 +148,7 @@ void rtlsdrProcessRaw(unsigned char *byteBuffer, uint32_t byteBufferLength, void
         if (RAW) {
             printRawValue(magnitudeBuffer[idx]);
         } else if (_debug) {
-            fprintf(stderr, "%04d (%04d / %04d) magnitude %04x  ", globalIndex + idx, idx, magnitudeCount, magnitudeBuffer[idx]);
+            fprintf(stderr, "%04" PRIu64 " (%04d / %04d) magnitude %04x  ", globalIndex + idx, idx, magnitudeCount, magnitudeBuffer[idx]);
             printValue(magnitudeBuffer[idx]);
         }
 
@@ -220,10 +223,10 @@ void rtlsdrProcessRaw(unsigned char *byteBuffer, uint32_t byteBufferLength, void
         }
 
         if ((!RAW) && (_debug)) {
-            fprintf(stderr, "%04d [foo] _____________________________________________________________________________________________ good preambule ________________________________________________________________________________________\n", globalIndex+idx);
+            fprintf(stderr, "%04" PRIu64 " [foo] _____________________________________________________________________________________________ good preambule ________________________________________________________________________________________\n", globalIndex+idx);
   
             for(int k=0; k<16; k++) {
-                fprintf(stderr, "    %04d (%04d / %04d) magnitude %04x  ", globalIndex +idx + k, idx + k, magnitudeCount, magnitudeBuffer[idx+k]);
+                fprintf(stderr, "    %04" PRIu64 " (%04d / %04d) magnitude %04x  ", globalIndex +idx + k, idx + k, magnitudeCount, magnitudeBuffer[idx+k]);
                 printValue(magnitudeBuffer[idx+k]);
             }
 
@@ -242,7 +245,7 @@ void rtlsdrProcessRaw(unsigned char *byteBuffer, uint32_t byteBufferLength, void
         }
 
         // No error ?
-        if (goRtlsrdData(message, messageLengthBit / 8, ctx) == 0) {
+        if (goRtlsrdData(message, messageLengthBit / 8, globalIndex + idx, meanHigh, ctx) == 0) {
             // jump over the message.
             idx += PREAMBULE_BIT_SIZE + messageLengthBit * 2;
             if ((RAW) || (_debug)) fprintf(stderr, "Jumping to %04d (%04d + %04d = %04d)\n", idx, PREAMBULE_BIT_SIZE, messageLengthBit * 2, PREAMBULE_BIT_SIZE + messageLengthBit * 2);
@@ -1,3 +1,5 @@
+//go:build !nortlsdr
+
 package implementations
 
 /*
@@ -11,13 +13,19 @@ import "C"
 import (
 	"context"
 	"fmt"
+	"time"
 	"unsafe"
 
 	localcontext "github.com/landru29/adsb1090/internal/input/context"
 	"github.com/landru29/adsb1090/internal/logger"
+	"github.com/landru29/adsb1090/internal/model"
 	"github.com/landru29/adsb1090/internal/processor"
+	"github.com/landru29/adsb1090/internal/stats"
 )
 
+// The samples are taken at 2 MHz: each sample is 6 ticks of the 12 MHz counter.
+const timestampPerSample = 6
+
 var debug string //nolint: gochecknoglobals
 
 // Device is a RTL-SDR device.
@@ -216,23 +224,25 @@ func (d *Device) ReadAsync(ctx context.Context, bufNum uint32, bufLen uint32) er
 	return nil
 }
 
-func processRaw(data []byte, cContext unsafe.Pointer) {
-	cstr := (*C.uchar)(unsafe.Pointer(C.CString(string(data))))
-
-	C.rtlsdrProcessRaw(cstr, C.uint(len(data)), cContext)
-
-	C.free(unsafe.Pointer(cstr))
-}
-
 //export goRtlsrdData
-func goRtlsrdData(buf *C.uchar, length C.uint32_t, cCtx *C.void) C.int {
+func goRtlsrdData(buf *C.uchar, length C.uint32_t, sampleIndex C.uint64_t, level C.uint16_t, cCtx *C.void) C.int {
 	ctx := localcontext.FromPtr(unsafe.Pointer(cCtx))
 	processors := localcontext.Processor(ctx)
 
-	mySlice := C.GoBytes(unsafe.Pointer(buf), C.int(length)) //nolint: nlreturn
+	// The C demodulator only calls back after a preamble.
+	if statistics, found := stats.FromContext(ctx); found {
+		statistics.Preamble()
+	}
+
+	frame := model.Frame{
+		ModeS:      C.GoBytes(unsafe.Pointer(buf), C.int(length)), //nolint: nlreturn
+		Timestamp:  uint64(sampleIndex) * timestampPerSample,
+		ReceivedAt: time.Now(),
+		RSSI:       model.RSSIFromMagnitude(uint16(level)),
+	}
 
 	for _, processor := range processors {
-		if err := processor.Process(mySlice); err != nil {
+		if err := processor.Process(frame); err != nil {
 			return -1
 		}
 	}
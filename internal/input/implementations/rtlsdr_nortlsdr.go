@@ -0,0 +1,71 @@
+//go:build nortlsdr
+
+package implementations
+
+import (
+	"context"
+
+	"github.com/landru29/adsb1090/internal/processor"
+)
+
+// Device is a RTL-SDR device.
+// Built with the nortlsdr tag, no device is ever available.
+type Device struct{}
+
+// InitTables generates tables for data extract.
+func InitTables() {}
+
+// DeviceCount searches for a compatible device.
+func DeviceCount() uint32 {
+	return 0
+}
+
+// OpenDevice opens the device.
+func OpenDevice(_ uint32, _ []processor.Processer) (*Device, error) {
+	return nil, ErrNoDeviceFound
+}
+
+// Close closes the device.
+func (d *Device) Close() error {
+	return ErrNoDeviceFound
+}
+
+// SetTunerGainMode sets the gain mode (automatic/manual) for the device.
+func (d *Device) SetTunerGainMode(_ bool) error {
+	return ErrNoDeviceFound
+}
+
+// SetTunerGain sets the gain for the device.
+func (d *Device) SetTunerGain(_ float64) error {
+	return ErrNoDeviceFound
+}
+
+// TunerGain read the current gain on the tuner.
+func (d *Device) TunerGain() float64 {
+	return 0
+}
+
+// SetAgcMode enables or disables the internal digital AGC of the RTL2832.
+func (d *Device) SetAgcMode(_ bool) error {
+	return ErrNoDeviceFound
+}
+
+// SetCenterFreq ...
+func (d *Device) SetCenterFreq(_ uint32) error {
+	return ErrNoDeviceFound
+}
+
+// SetSampleRate sets the sample rate for the device.
+func (d *Device) SetSampleRate(_ uint32) error {
+	return ErrNoDeviceFound
+}
+
+// ResetBuffer resets the device internal buffer.
+func (d *Device) ResetBuffer() error {
+	return ErrNoDeviceFound
+}
+
+// ReadAsync reads samples from the device asynchronously.
+func (d *Device) ReadAsync(_ context.Context, _ uint32, _ uint32) error {
+	return ErrNoDeviceFound
+}
@@ -0,0 +1,300 @@
+package implementations
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/avr"
+	"github.com/landru29/adsb1090/internal/beast"
+	"github.com/landru29/adsb1090/internal/logger"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/processor"
+)
+
+const (
+	defaultStreamReconnectDelay = 5 * time.Second
+)
+
+// frameDecoder reads the Mode S frames of a stream, until io.EOF.
+type frameDecoder func(rd io.Reader) func() (model.Frame, error)
+
+// Stream is the TCP source of already demodulated frames.
+type Stream struct {
+	name           string
+	addr           string
+	listen         bool
+	reconnectDelay time.Duration
+	decoder        frameDecoder
+	mutex          sync.Mutex
+}
+
+// StreamConfigurator is the Stream source configurator.
+type StreamConfigurator func(*Stream)
+
+// NewBeast creates a new Beast source.
+func NewBeast(addr string, opts ...StreamConfigurator) *Stream {
+	return newStream("beast", addr, beastDecoder, opts...)
+}
+
+// NewAVR creates a new AVR source.
+func NewAVR(addr string, opts ...StreamConfigurator) *Stream {
+	return newStream("avr", addr, avrDecoder, opts...)
+}
+
+func newStream(name string, addr string, decoder frameDecoder, opts ...StreamConfigurator) *Stream {
+	output := &Stream{
+		name:           name,
+		addr:           addr,
+		reconnectDelay: defaultStreamReconnectDelay,
+		decoder:        decoder,
+	}
+
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	return output
+}
+
+// WithListen waits for the streams instead of connecting to the remote.
+func WithListen() StreamConfigurator {
+	return func(s *Stream) {
+		s.listen = true
+	}
+}
+
+// WithReconnectDelay is the delay before connecting again to the remote.
+func WithReconnectDelay(delay time.Duration) StreamConfigurator {
+	return func(s *Stream) {
+		s.reconnectDelay = delay
+	}
+}
+
+// Start implements the input.Starter interface.
+func (s *Stream) Start(ctx context.Context, processors ...processor.Processer) error {
+	log, found := logger.Logger(ctx)
+	if !found {
+		log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	log = log.With("input", s.name, "addr", s.addr)
+
+	if s.listen {
+		return s.serve(ctx, log, processors)
+	}
+
+	return s.dial(ctx, log, processors)
+}
+
+func (s *Stream) dial(ctx context.Context, log *slog.Logger, processors []processor.Processer) error {
+	dialer := &net.Dialer{}
+
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+		if err == nil {
+			log.Info("connected")
+
+			err = s.consume(ctx, log, conn, processors)
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Error("disconnected", "msg", err, "retry", s.reconnectDelay)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.reconnectDelay):
+		}
+	}
+}
+
+func (s *Stream) serve(ctx context.Context, log *slog.Logger, processors []processor.Processer) error {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	log.Info("listening")
+
+	waitGroup := sync.WaitGroup{}
+
+	defer waitGroup.Wait()
+
+	go func() {
+		<-ctx.Done()
+
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		waitGroup.Add(1)
+
+		go func(conn net.Conn) {
+			defer waitGroup.Done()
+
+			remoteLog := log.With("remote", conn.RemoteAddr().String())
+
+			remoteLog.Info("new stream")
+
+			if err := s.consume(ctx, remoteLog, conn, processors); err != nil {
+				remoteLog.Error("stream closed", "msg", err)
+			}
+		}(conn)
+	}
+}
+
+// consume sends the frames of a connection to the processors, until the connection is closed.
+func (s *Stream) consume(
+	ctx context.Context,
+	log *slog.Logger,
+	conn net.Conn,
+	processors []processor.Processer,
+) error {
+	done := make(chan struct{})
+
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+
+		_ = conn.Close()
+	}()
+
+	err := processFrames(log, s.decoder(conn), &s.mutex, processors)
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
+// FrameReader is the source of already demodulated frames, read from a file or any reader.
+type FrameReader struct {
+	filename string
+	reader   io.Reader
+	decoder  frameDecoder
+}
+
+// NewAVRReader creates a new AVR source from a reader (ie: stdin).
+func NewAVRReader(rd io.Reader) *FrameReader {
+	return &FrameReader{
+		reader:  rd,
+		decoder: avrDecoder,
+	}
+}
+
+// NewAVRFile creates a new AVR source from a file.
+func NewAVRFile(filename string) *FrameReader {
+	return &FrameReader{
+		filename: filename,
+		decoder:  avrDecoder,
+	}
+}
+
+// Start implements the input.Starter interface.
+func (r *FrameReader) Start(ctx context.Context, processors ...processor.Processer) error {
+	log, found := logger.Logger(ctx)
+	if !found {
+		log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	reader := r.reader
+
+	if r.filename != "" {
+		fileDescriptor, err := os.Open(r.filename)
+		if err != nil {
+			return err
+		}
+
+		defer func(closer io.Closer) {
+			_ = closer.Close()
+		}(fileDescriptor)
+
+		reader = fileDescriptor
+	}
+
+	return processFrames(log, r.decoder(reader), &sync.Mutex{}, processors)
+}
+
+// processFrames sends all the frames to the processors, until io.EOF.
+// The mutex serializes the calls to the processors, as several streams can be read at the same time.
+func processFrames(
+	log *slog.Logger,
+	next func() (model.Frame, error),
+	mutex *sync.Mutex,
+	processors []processor.Processer,
+) error {
+	for {
+		frame, err := next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		log.Debug("frame", "timestamp", frame.Timestamp, "signal", frame.Signal)
+
+		if frame.ReceivedAt.IsZero() {
+			frame.ReceivedAt = time.Now()
+		}
+
+		mutex.Lock()
+
+		for _, proc := range processors {
+			_ = proc.Process(frame)
+		}
+
+		mutex.Unlock()
+	}
+}
+
+func beastDecoder(rd io.Reader) func() (model.Frame, error) {
+	reader := beast.NewReader(rd)
+
+	return func() (model.Frame, error) {
+		for {
+			frame, err := reader.Next()
+			if err != nil {
+				return model.Frame{}, err
+			}
+
+			if !frame.ModeS() {
+				continue
+			}
+
+			return model.Frame{
+				ModeS:     frame.Data,
+				Timestamp: frame.Timestamp,
+				Signal:    frame.Signal,
+				RSSI:      model.RSSIFromSignal(frame.Signal),
+			}, nil
+		}
+	}
+}
+
+func avrDecoder(rd io.Reader) func() (model.Frame, error) {
+	return avr.NewReader(rd).Next
+}
@@ -0,0 +1,247 @@
+package implementations_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/avr"
+	"github.com/landru29/adsb1090/internal/beast"
+	"github.com/landru29/adsb1090/internal/input/implementations"
+	"github.com/landru29/adsb1090/internal/mocks"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordedStream is the encoding of the recorded frames.
+func recordedStream(t *testing.T, encode func(frame model.Frame) []byte) ([]string, []byte) {
+	t.Helper()
+
+	file, err := os.Open("../../../testdata/modes1.txt")
+	require.NoError(t, err)
+
+	defer func(closer io.Closer) {
+		require.NoError(t, closer.Close())
+	}(file)
+
+	frames := []string{}
+	stream := []byte{}
+
+	scanner := bufio.NewScanner(file)
+	for idx := 0; scanner.Scan(); idx++ {
+		frame := strings.Trim(scanner.Text(), "*;")
+
+		data, err := hex.DecodeString(frame)
+		require.NoError(t, err)
+
+		frames = append(frames, frame)
+		stream = append(stream, encode(model.Frame{
+			ModeS:     data,
+			Timestamp: uint64(idx) * 12000, //nolint: gomnd
+			Signal:    uint8(idx),
+		})...)
+	}
+
+	return frames, stream
+}
+
+func beastEncoder(t *testing.T) func(frame model.Frame) []byte {
+	t.Helper()
+
+	return func(frame model.Frame) []byte {
+		encoded, err := beast.Frame{
+			Timestamp: frame.Timestamp,
+			Signal:    frame.Signal,
+			Data:      frame.ModeS,
+		}.MarshalBinary()
+		require.NoError(t, err)
+
+		return encoded
+	}
+}
+
+// collector cancels the context when all the expected frames were processed.
+func collector(
+	t *testing.T,
+	cancel context.CancelFunc,
+	expected int,
+) (*mocks.MockProcesser, func() []string) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mutex := sync.Mutex{}
+	frames := []string{}
+
+	mockProcessor := mocks.NewMockProcesser(ctrl)
+	mockProcessor.EXPECT().Process(gomock.Any()).DoAndReturn(func(frame model.Frame) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		frames = append(frames, frame.ModeS.String())
+		if len(frames) == expected {
+			cancel()
+		}
+
+		return nil
+	}).AnyTimes()
+
+	return mockProcessor, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return frames
+	}
+}
+
+func TestBeast(t *testing.T) {
+	t.Parallel()
+
+	frames, stream := recordedStream(t, beastEncoder(t))
+
+	t.Run("dial", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = listener.Close()
+		})
+
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			defer func(closer io.Closer) {
+				_ = closer.Close()
+			}(conn)
+
+			// split the stream to check the frames across several reads.
+			for remaining := stream; len(remaining) > 0; {
+				size := min(1000, len(remaining)) //nolint: gomnd
+
+				if _, err := conn.Write(remaining[:size]); err != nil {
+					return
+				}
+
+				remaining = remaining[size:]
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		t.Cleanup(cancel)
+
+		mockProcessor, processed := collector(t, cancel, len(frames))
+
+		require.NoError(t, implementations.NewBeast(listener.Addr().String()).Start(ctx, mockProcessor))
+
+		assert.Equal(t, frames, processed())
+	})
+
+	t.Run("listen", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		addr := listener.Addr().String()
+
+		require.NoError(t, listener.Close())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		t.Cleanup(cancel)
+
+		mockProcessor, processed := collector(t, cancel, len(frames))
+
+		go func() {
+			for ctx.Err() == nil {
+				conn, err := net.Dial("tcp", addr)
+				if err != nil {
+					time.Sleep(10 * time.Millisecond)
+
+					continue
+				}
+
+				_, _ = conn.Write(stream)
+
+				_ = conn.Close()
+
+				return
+			}
+		}()
+
+		require.NoError(t, implementations.NewBeast(addr, implementations.WithListen()).Start(ctx, mockProcessor))
+
+		assert.Equal(t, frames, processed())
+	})
+}
+
+func TestAVR(t *testing.T) {
+	t.Parallel()
+
+	frames, stream := recordedStream(t, avr.Marshal)
+
+	t.Run("dial", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = listener.Close()
+		})
+
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			_, _ = conn.Write(stream)
+
+			_ = conn.Close()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		t.Cleanup(cancel)
+
+		mockProcessor, processed := collector(t, cancel, len(frames))
+
+		require.NoError(t, implementations.NewAVR(listener.Addr().String()).Start(ctx, mockProcessor))
+
+		assert.Equal(t, frames, processed())
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		t.Parallel()
+
+		mockProcessor, processed := collector(t, func() {}, len(frames))
+
+		require.NoError(t, implementations.NewAVRReader(bytes.NewReader(stream)).Start(context.Background(), mockProcessor))
+
+		assert.Equal(t, frames, processed())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		t.Parallel()
+
+		mockProcessor, processed := collector(t, func() {}, len(frames))
+
+		require.NoError(t, implementations.NewAVRFile("../../../testdata/modes1.txt").Start(context.Background(), mockProcessor))
+
+		assert.Equal(t, frames, processed())
+	})
+}
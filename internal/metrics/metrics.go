@@ -0,0 +1,225 @@
+// Package metrics exposes the receiver activity to Prometheus.
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/landru29/adsb1090/internal/database"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/stats"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "adsb1090"
+
+var ( //nolint: gochecknoglobals
+	preamblesDesc = newDesc("preambles_total", "Preambles detected by the demodulators.")
+	framesDesc    = newDesc("frames_total", "Frames received by the decoder.")
+	crcDesc       = newDesc("crc_failures_total", "Frames dropped, with a wrong parity.")
+	correctedDesc = newDesc("corrected_total", "Frames repaired.")
+	addressDesc   = newDesc("address_rejections_total", "Address/parity frames of unconfirmed aircraft.")
+	messagesDesc  = newDesc("messages_total", "Accepted messages, by downlink format.", "df")
+	positionsDesc = newDesc("positions_total", "Positions decoded.")
+	rejectedDesc  = newDesc("position_rejections_total", "Positions rejected.")
+	errorsDesc    = newDesc("transport_errors_total", "Transport errors, by transporter.", "transporter")
+	clientsDesc   = newDesc("transporter_clients", "Connected clients, by transporter.", "transporter")
+	storageDesc   = newDesc("storage_elements", "Stored elements, by storage.", "storage")
+	aircraftDesc  = newDesc("aircraft", "Tracked aircraft.")
+	locatedDesc   = newDesc("aircraft_with_position", "Tracked aircraft with a position.")
+	adsbDesc      = newDesc("aircraft_with_adsb", "Tracked aircraft broadcasting ADS-B.")
+	rangeDesc     = newDesc("max_range_meters", "Distance from the receiver to the farthest tracked aircraft.")
+)
+
+func newDesc(name string, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, nil)
+}
+
+// ClientCounter is a transporter with connected clients.
+type ClientCounter interface {
+	fmt.Stringer
+	Clients() int
+}
+
+// Configurator is the Collector configurator.
+type Configurator func(*Collector)
+
+// Collector implements the prometheus.Collector interface.
+// The values are read from their sources at each scrape. The sources can be added on a nil Collector.
+type Collector struct {
+	mutex        sync.Mutex
+	stats        *stats.Stats
+	aircraftDB   *database.ElementStorage[model.ICAOAddr, model.Aircraft]
+	receiver     *model.Receiver
+	transporters []ClientCounter
+	storages     map[string]database.Sizer
+}
+
+// New creates the collector.
+func New(opts ...Configurator) *Collector {
+	output := &Collector{
+		storages: map[string]database.Sizer{},
+	}
+
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	return output
+}
+
+// WithStats reads the counters from the receiver statistics.
+func WithStats(statistics *stats.Stats) Configurator {
+	return func(c *Collector) {
+		c.stats = statistics
+	}
+}
+
+// WithAircraftDatabase reads the tracked aircraft from the database.
+func WithAircraftDatabase(aircraftDB *database.ElementStorage[model.ICAOAddr, model.Aircraft]) Configurator {
+	return func(c *Collector) {
+		c.aircraftDB = aircraftDB
+	}
+}
+
+// WithReceiver sets the receiver location, to measure the range.
+func WithReceiver(receiver model.Receiver) Configurator {
+	return func(c *Collector) {
+		c.receiver = &receiver
+	}
+}
+
+// AddTransporter exposes the connected clients of a transporter.
+func (c *Collector) AddTransporter(transporter ClientCounter) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.transporters = append(c.transporters, transporter)
+}
+
+// AddStorage exposes the size of a storage.
+func (c *Collector) AddStorage(name string, storage database.Sizer) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.storages[name] = storage
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *Collector) Describe(descs chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		preamblesDesc, framesDesc, crcDesc, correctedDesc, addressDesc, messagesDesc, positionsDesc, rejectedDesc,
+		errorsDesc, clientsDesc, storageDesc, aircraftDesc, locatedDesc, adsbDesc, rangeDesc,
+	} {
+		descs <- desc
+	}
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *Collector) Collect(metrics chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.collectStats(metrics)
+	c.collectAircraft(metrics)
+
+	for _, transporter := range c.transporters {
+		metrics <- prometheus.MustNewConstMetric(
+			clientsDesc,
+			prometheus.GaugeValue,
+			float64(transporter.Clients()),
+			transporter.String(),
+		)
+	}
+
+	for name, storage := range c.storages {
+		metrics <- prometheus.MustNewConstMetric(storageDesc, prometheus.GaugeValue, float64(storage.Len()), name)
+	}
+}
+
+func (c *Collector) collectStats(metrics chan<- prometheus.Metric) {
+	if c.stats == nil {
+		return
+	}
+
+	total := c.stats.Report().Total
+
+	for desc, value := range map[*prometheus.Desc]uint64{
+		preamblesDesc: total.Preambles,
+		framesDesc:    total.Frames,
+		crcDesc:       total.CRCFailures,
+		correctedDesc: total.Corrected,
+		addressDesc:   total.AddressRejections,
+		positionsDesc: total.Positions,
+		rejectedDesc:  total.PositionRejections,
+	} {
+		metrics <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value))
+	}
+
+	for downlinkFormat, value := range total.Messages {
+		metrics <- prometheus.MustNewConstMetric(
+			messagesDesc,
+			prometheus.CounterValue,
+			float64(value),
+			strconv.Itoa(int(downlinkFormat)),
+		)
+	}
+
+	for name, value := range total.TransporterErrors {
+		metrics <- prometheus.MustNewConstMetric(errorsDesc, prometheus.CounterValue, float64(value), name)
+	}
+}
+
+func (c *Collector) collectAircraft(metrics chan<- prometheus.Metric) {
+	if c.aircraftDB == nil {
+		return
+	}
+
+	var (
+		tracked  int
+		located  int
+		adsb     int
+		maxRange float64
+	)
+
+	for _, addr := range c.aircraftDB.Keys() {
+		// the element may have expired since the keys were listed.
+		aircraft := c.aircraftDB.Element(addr)
+		if aircraft == nil {
+			continue
+		}
+
+		tracked++
+
+		if aircraft.Source == model.SourceADSB {
+			adsb++
+		}
+
+		if aircraft.Position == nil {
+			continue
+		}
+
+		located++
+
+		if c.receiver != nil {
+			maxRange = max(maxRange, c.receiver.Position.Distance(*aircraft.Position))
+		}
+	}
+
+	metrics <- prometheus.MustNewConstMetric(aircraftDesc, prometheus.GaugeValue, float64(tracked))
+	metrics <- prometheus.MustNewConstMetric(locatedDesc, prometheus.GaugeValue, float64(located))
+	metrics <- prometheus.MustNewConstMetric(adsbDesc, prometheus.GaugeValue, float64(adsb))
+
+	if c.receiver != nil {
+		metrics <- prometheus.MustNewConstMetric(rangeDesc, prometheus.GaugeValue, maxRange)
+	}
+}
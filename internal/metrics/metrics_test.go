@@ -0,0 +1,121 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/database"
+	"github.com/landru29/adsb1090/internal/metrics"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/stats"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type clientCounter struct {
+	name    string
+	clients int
+}
+
+func (c clientCounter) Clients() int {
+	return c.clients
+}
+
+func (c clientCounter) String() string {
+	return c.name
+}
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	statistics := stats.New()
+	statistics.Frame()
+	statistics.Frame()
+	statistics.CRCFailure()
+	statistics.Corrected()
+	statistics.Transported("tcp", errors.New("broken pipe"))
+
+	aircraftDB := database.NewElementStorage[model.ICAOAddr, model.Aircraft](
+		ctx,
+		database.ElementWithLifetime[model.ICAOAddr, model.Aircraft](time.Minute),
+	)
+
+	aircraftDB.Add(0x4840d6, model.Aircraft{
+		Source:   model.SourceADSB,
+		Position: &model.Position{Latitude: 49, Longitude: 2},
+	})
+	aircraftDB.Add(0x40621d, model.Aircraft{
+		Source:   model.SourceADSB,
+		Position: &model.Position{Latitude: 48, Longitude: 3},
+	})
+	aircraftDB.Add(0x3c6586, model.Aircraft{})
+
+	receiver := model.Receiver{Position: model.Position{Latitude: 48, Longitude: 2}}
+
+	collector := metrics.New(
+		metrics.WithStats(statistics),
+		metrics.WithAircraftDatabase(aircraftDB),
+		metrics.WithReceiver(receiver),
+	)
+
+	collector.AddTransporter(clientCounter{name: "tcp", clients: 2})
+	collector.AddStorage("aircraft", aircraftDB)
+
+	// The farthest aircraft is one degree of latitude north of the receiver.
+	maxRange := receiver.Position.Distance(model.Position{Latitude: 49, Longitude: 2})
+
+	expected := `
+# HELP adsb1090_aircraft Tracked aircraft.
+# TYPE adsb1090_aircraft gauge
+adsb1090_aircraft 3
+# HELP adsb1090_aircraft_with_adsb Tracked aircraft broadcasting ADS-B.
+# TYPE adsb1090_aircraft_with_adsb gauge
+adsb1090_aircraft_with_adsb 2
+# HELP adsb1090_aircraft_with_position Tracked aircraft with a position.
+# TYPE adsb1090_aircraft_with_position gauge
+adsb1090_aircraft_with_position 2
+# HELP adsb1090_corrected_total Frames repaired.
+# TYPE adsb1090_corrected_total counter
+adsb1090_corrected_total 1
+# HELP adsb1090_crc_failures_total Frames dropped, with a wrong parity.
+# TYPE adsb1090_crc_failures_total counter
+adsb1090_crc_failures_total 1
+# HELP adsb1090_frames_total Frames received by the decoder.
+# TYPE adsb1090_frames_total counter
+adsb1090_frames_total 2
+# HELP adsb1090_max_range_meters Distance from the receiver to the farthest tracked aircraft.
+# TYPE adsb1090_max_range_meters gauge
+adsb1090_max_range_meters ` + strconv.FormatFloat(maxRange, 'g', -1, 64) + `
+# HELP adsb1090_storage_elements Stored elements, by storage.
+# TYPE adsb1090_storage_elements gauge
+adsb1090_storage_elements{storage="aircraft"} 3
+# HELP adsb1090_transport_errors_total Transport errors, by transporter.
+# TYPE adsb1090_transport_errors_total counter
+adsb1090_transport_errors_total{transporter="tcp"} 1
+# HELP adsb1090_transporter_clients Connected clients, by transporter.
+# TYPE adsb1090_transporter_clients gauge
+adsb1090_transporter_clients{transporter="tcp"} 2
+`
+
+	require.NoError(t, testutil.CollectAndCompare(
+		collector,
+		strings.NewReader(expected),
+		"adsb1090_aircraft",
+		"adsb1090_aircraft_with_adsb",
+		"adsb1090_aircraft_with_position",
+		"adsb1090_corrected_total",
+		"adsb1090_crc_failures_total",
+		"adsb1090_frames_total",
+		"adsb1090_max_range_meters",
+		"adsb1090_storage_elements",
+		"adsb1090_transport_errors_total",
+		"adsb1090_transporter_clients",
+	))
+}
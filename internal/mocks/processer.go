@@ -11,6 +11,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	model "github.com/landru29/adsb1090/internal/model"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -38,15 +39,15 @@ func (m *MockProcesser) EXPECT() *MockProcesserMockRecorder {
 }
 
 // Process mocks base method.
-func (m *MockProcesser) Process(data []byte) error {
+func (m *MockProcesser) Process(frame model.Frame) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Process", data)
+	ret := m.ctrl.Call(m, "Process", frame)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Process indicates an expected call of Process.
-func (mr *MockProcesserMockRecorder) Process(data any) *gomock.Call {
+func (mr *MockProcesserMockRecorder) Process(frame any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockProcesser)(nil).Process), data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockProcesser)(nil).Process), frame)
 }
@@ -0,0 +1,128 @@
+// Package modeac decodes the Mode A (identity) and Mode C (Gillham altitude) codes.
+package modeac
+
+import (
+	"github.com/landru29/adsb1090/internal/errors"
+)
+
+const (
+	// ErrInvalidAltitude is when the code is not a valid Gillham altitude.
+	ErrInvalidAltitude errors.Error = "invalid Gillham altitude code"
+
+	// invalidAltitudeMask are the bits never set in a Gillham altitude: the nibble paddings and D1.
+	invalidAltitudeMask = 0x8889
+
+	// hundredsMask are the C bits, at least one is set in a Gillham altitude.
+	hundredsMask = 0x0070
+
+	// altitudeOffset is the Gillham altitude origin, in hundreds of feet (-1300 ft).
+	altitudeOffset = 13
+)
+
+// Code is the 12 bits Mode A/C code; each octal digit is stored in a nibble (0xABCD),
+// with the bits X4 X2 X1.
+//
+//	┏━━━━━━━━━━┯━━━━━━━━━━┯━━━━━━━━━━┯━━━━━━━━━━┓
+//	┃ - A4A2A1 | - B4B2B1 | - C4C2C1 | - D4D2D1 ┃
+//	┗━━━━━━━━━━┷━━━━━━━━━━┷━━━━━━━━━━┷━━━━━━━━━━┛
+type Code uint16
+
+// FromField reorders the 13 bits identity or altitude field of the Mode S replies.
+// The M bit is ignored.
+//
+//	┏━━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━━┓
+//	┃ C1 | A1 | C2 | A2 | C4 | A4 | M | B1 | D1 | B2 | D2 | B4 | D4 ┃
+//	┗━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━━┛
+func FromField(field uint16) Code {
+	return Code(
+		bit(field, 0x1000, 0x0010) | // C1
+			bit(field, 0x0800, 0x1000) | // A1
+			bit(field, 0x0400, 0x0020) | // C2
+			bit(field, 0x0200, 0x2000) | // A2
+			bit(field, 0x0100, 0x0040) | // C4
+			bit(field, 0x0080, 0x4000) | // A4
+			bit(field, 0x0020, 0x0100) | // B1
+			bit(field, 0x0010, 0x0001) | // D1
+			bit(field, 0x0008, 0x0200) | // B2
+			bit(field, 0x0004, 0x0002) | // D2
+			bit(field, 0x0002, 0x0400) | // B4
+			bit(field, 0x0001, 0x0004), // D4
+	)
+}
+
+// FromSquawk is the code of a 4 octal digits squawk (7700 for instance).
+func FromSquawk(squawk uint16) Code {
+	return Code((squawk/1000%10)<<12 | (squawk/100%10)<<8 | (squawk/10%10)<<4 | squawk%10) //nolint: gomnd
+}
+
+func bit(field uint16, from uint16, to uint16) uint16 {
+	if field&from == 0 {
+		return 0
+	}
+
+	return to
+}
+
+// Squawk is the 4 octal digits of the code, as a decimal number (7700 for instance).
+func (c Code) Squawk() uint16 {
+	return uint16(c>>12&0x7)*1000 + uint16(c>>8&0x7)*100 + uint16(c>>4&0x7)*10 + uint16(c&0x7) //nolint: gomnd
+}
+
+// Altitude decodes the Gillham code, in feet with 100 feet increments.
+// The 500 feet increments are Gray coded on D2 D4 A1 A2 A4 B1 B2 B4,
+// the 100 feet increments are coded on C1 C2 C4 and reflected when the 500 feet count is odd.
+func (c Code) Altitude() (int, error) {
+	if c&invalidAltitudeMask != 0 || c&hundredsMask == 0 {
+		return 0, ErrInvalidAltitude
+	}
+
+	oneHundreds := 0
+
+	for _, elt := range []struct {
+		mask Code
+		gray int
+	}{
+		{mask: 0x0010, gray: 0x007}, // C1
+		{mask: 0x0020, gray: 0x003}, // C2
+		{mask: 0x0040, gray: 0x001}, // C4
+	} {
+		if c&elt.mask != 0 {
+			oneHundreds ^= elt.gray
+		}
+	}
+
+	// 7 is reordered to 5, and 5 to 7.
+	if oneHundreds&5 == 5 { //nolint: gomnd
+		oneHundreds ^= 2
+	}
+
+	if oneHundreds > 5 { //nolint: gomnd
+		return 0, ErrInvalidAltitude
+	}
+
+	fiveHundreds := 0
+
+	for _, elt := range []struct {
+		mask Code
+		gray int
+	}{
+		{mask: 0x0002, gray: 0x0ff}, // D2
+		{mask: 0x0004, gray: 0x07f}, // D4
+		{mask: 0x1000, gray: 0x03f}, // A1
+		{mask: 0x2000, gray: 0x01f}, // A2
+		{mask: 0x4000, gray: 0x00f}, // A4
+		{mask: 0x0100, gray: 0x007}, // B1
+		{mask: 0x0200, gray: 0x003}, // B2
+		{mask: 0x0400, gray: 0x001}, // B4
+	} {
+		if c&elt.mask != 0 {
+			fiveHundreds ^= elt.gray
+		}
+	}
+
+	if fiveHundreds&1 == 1 {
+		oneHundreds = 6 - oneHundreds //nolint: gomnd
+	}
+
+	return (fiveHundreds*5 + oneHundreds - altitudeOffset) * 100, nil //nolint: gomnd
+}
@@ -0,0 +1,79 @@
+package modeac_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/modeac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAltitude(t *testing.T) {
+	t.Parallel()
+
+	for _, fixtureElt := range []struct {
+		squawk   uint16
+		altitude int
+	}{
+		{squawk: 40, altitude: -1200},
+		{squawk: 20, altitude: -1000},
+		{squawk: 620, altitude: 0},
+		{squawk: 630, altitude: 100},
+		{squawk: 220, altitude: 500},
+		{squawk: 320, altitude: 1000},
+		{squawk: 110, altitude: 2300},
+		{squawk: 6520, altitude: 10000},
+		{squawk: 2340, altitude: 12700},
+		{squawk: 5124, altitude: 35000},
+		{squawk: 5424, altitude: 38000},
+		{squawk: 2514, altitude: 50200},
+		{squawk: 44, altitude: 62700},
+		{squawk: 42, altitude: 126700},
+	} {
+		fixture := fixtureElt
+
+		t.Run(fmt.Sprintf("%04d", fixture.squawk), func(t *testing.T) {
+			t.Parallel()
+
+			altitude, err := modeac.FromSquawk(fixture.squawk).Altitude()
+			require.NoError(t, err)
+			assert.Equal(t, fixture.altitude, altitude)
+		})
+	}
+}
+
+func TestInvalidAltitude(t *testing.T) {
+	t.Parallel()
+
+	for _, squawk := range []uint16{
+		0,    // no C bit
+		7000, // no C bit
+		21,   // D1
+		50,   // C1 C4
+		70,   // C1 C2 C4
+	} {
+		_, err := modeac.FromSquawk(squawk).Altitude()
+		require.ErrorIs(t, err, modeac.ErrInvalidAltitude, "%04d", squawk)
+	}
+}
+
+func TestFromField(t *testing.T) {
+	t.Parallel()
+
+	for _, fixture := range []struct {
+		field  uint16
+		squawk uint16
+	}{
+		{field: 0x0100, squawk: 40},
+		{field: 0x040a, squawk: 620},
+		{field: 0x140a, squawk: 630},
+		{field: 0x0ca1, squawk: 5124},
+		{field: 0x0c83, squawk: 5424},
+		{field: 0x1fbf, squawk: 7777},
+		{field: 0x1fff, squawk: 7777},
+	} {
+		assert.Equal(t, fixture.squawk, modeac.FromField(fixture.field).Squawk(), "%04x", fixture.field)
+		assert.Equal(t, modeac.FromSquawk(fixture.squawk), modeac.FromField(fixture.field), "%04x", fixture.field)
+	}
+}
@@ -0,0 +1,251 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/landru29/adsb1090/internal/binary"
+)
+
+//       ┏━━━━┓
+//       ┃ 0  ┃  Short air-air surveillance
+//       ┣━━━━╇━━━━┯━━━━┯━━━┯━━━━┯━━━┯━━━━┯━━━┯━━━━┯━━━━━━━━┓
+//       ┃ DF | VS | CC | - | SL | - | RI | - | AC | Parity ┃
+//       ┠┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┼┈┈┈┈┼┈┈┈┼┈┈┈┈┼┈┈┈┼┈┈┈┈┼┈┈┈┈┈┈┈┈┨
+//       ┃ 5  |  1 |  1 | 1 |  3 | 2 |  4 | 2 | 13 |   24   ┃
+//       ┗━━━━┷━━━━┷━━━━┷━━━┷━━━━┷━━━┷━━━━┷━━━┷━━━━┷━━━━━━━━┛
+//       1    6    7    8   9    12  14   18  20   33
+//
+//
+//       ┏━━━━┓
+//       ┃ 16 ┃  Long air-air surveillance
+//       ┣━━━━╇━━━━┯━━━┯━━━━┯━━━┯━━━━┯━━━┯━━━━┯━━━━┯━━━━━━━━┓
+//       ┃ DF | VS | - | SL | - | RI | - | AC | MV | Parity ┃
+//       ┠┈┈┈┈┼┈┈┈┈┼┈┈┈┼┈┈┈┈┼┈┈┈┼┈┈┈┈┼┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┈┈┈┈┨
+//       ┃ 5  |  1 | 2 |  3 | 2 |  4 | 2 | 13 | 56 |   24   ┃
+//       ┗━━━━┷━━━━┷━━━┷━━━━┷━━━┷━━━━┷━━━┷━━━━┷━━━━┷━━━━━━━━┛
+//       1    6    7   9    12  14   18  20   33   89
+//
+//       ┏━━━━┓
+//       ┃ MV ┃  Resolution advisory report
+//       ┣━━━━╇━━━━━┯━━━━━┯━━━━━┯━━━━━┯━━━━━┯━━━━━┓
+//       ┃VDS | ARA | RAC | RAT | MTE | TTI | TID ┃
+//       ┠┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┨
+//       ┃ 8  |  14 |  4  |  1  |  1  |  2  |  26 ┃
+//       ┗━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┛
+
+const (
+	// resolutionAdvisoryReport is the VDS of a resolution advisory report (3,0).
+	resolutionAdvisoryReport = 0x30
+)
+
+// ReplyInformation is the RI field: the ACAS capability (0-7) or the maximum cruising airspeed (8-15).
+type ReplyInformation uint8
+
+const (
+	// ReplyInformationNoACAS : no operating ACAS.
+	ReplyInformationNoACAS ReplyInformation = 0
+	// ReplyInformationInhibited : ACAS with resolution capability inhibited.
+	ReplyInformationInhibited ReplyInformation = 2
+	// ReplyInformationVertical : ACAS with vertical-only resolution capability.
+	ReplyInformationVertical ReplyInformation = 3
+	// ReplyInformationVerticalHorizontal : ACAS with vertical and horizontal resolution capability.
+	ReplyInformationVerticalHorizontal ReplyInformation = 4
+	// ReplyInformationNoAirspeed : no maximum airspeed data available.
+	ReplyInformationNoAirspeed ReplyInformation = 8
+	// ReplyInformationAirspeed75 : maximum airspeed up to 75 kt.
+	ReplyInformationAirspeed75 ReplyInformation = 9
+	// ReplyInformationAirspeed150 : maximum airspeed from 75 to 150 kt.
+	ReplyInformationAirspeed150 ReplyInformation = 10
+	// ReplyInformationAirspeed300 : maximum airspeed from 150 to 300 kt.
+	ReplyInformationAirspeed300 ReplyInformation = 11
+	// ReplyInformationAirspeed600 : maximum airspeed from 300 to 600 kt.
+	ReplyInformationAirspeed600 ReplyInformation = 12
+	// ReplyInformationAirspeed1200 : maximum airspeed from 600 to 1200 kt.
+	ReplyInformationAirspeed1200 ReplyInformation = 13
+	// ReplyInformationAirspeedMore : maximum airspeed more than 1200 kt.
+	ReplyInformationAirspeedMore ReplyInformation = 14
+
+	maxReplyInformation ReplyInformation = 15
+)
+
+// IsAirspeed checks if the reply information is a maximum airspeed.
+func (r ReplyInformation) IsAirspeed() bool {
+	return r >= ReplyInformationNoAirspeed
+}
+
+// String implements the Stringer interface.
+func (r ReplyInformation) String() string { //nolint: cyclop
+	switch r {
+	case ReplyInformationNoACAS:
+		return "no ACAS"
+	case ReplyInformationInhibited:
+		return "ACAS, resolution inhibited"
+	case ReplyInformationVertical:
+		return "ACAS, vertical resolution"
+	case ReplyInformationVerticalHorizontal:
+		return "ACAS, vertical and horizontal resolution"
+	case ReplyInformationNoAirspeed:
+		return "no maximum airspeed"
+	case ReplyInformationAirspeed75:
+		return "max airspeed <= 75 kt"
+	case ReplyInformationAirspeed150:
+		return "max airspeed 75-150 kt"
+	case ReplyInformationAirspeed300:
+		return "max airspeed 150-300 kt"
+	case ReplyInformationAirspeed600:
+		return "max airspeed 300-600 kt"
+	case ReplyInformationAirspeed1200:
+		return "max airspeed 600-1200 kt"
+	case ReplyInformationAirspeedMore:
+		return "max airspeed > 1200 kt"
+	default:
+		return fmt.Sprintf("reserved (%d)", uint8(r))
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r ReplyInformation) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *ReplyInformation) UnmarshalText(data []byte) error {
+	for value := ReplyInformationNoACAS; value <= maxReplyInformation; value++ {
+		if value.String() == string(data) {
+			*r = value
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: reply information %s", ErrUnsupportedFormat, data)
+}
+
+// ACAS is the airborne collision avoidance system status, reported in the air-air surveillance replies.
+type ACAS struct {
+	SensitivityLevel uint8             `json:"sensitivityLevel"` /* 0: inoperative */
+	CrossLink        bool              `json:"crossLink"`        /* Supports the cross-link capability. */
+	Capability       *ReplyInformation `json:"capability,omitempty"`
+	MaxAirspeed      *ReplyInformation `json:"maxAirspeed,omitempty"`
+}
+
+// String implements the Stringer interface.
+func (a ACAS) String() string {
+	output := fmt.Sprintf("sensitivity %d", a.SensitivityLevel)
+
+	if a.CrossLink {
+		output += ", cross-link"
+	}
+
+	if a.Capability != nil {
+		output += ", " + a.Capability.String()
+	}
+
+	if a.MaxAirspeed != nil {
+		output += ", " + a.MaxAirspeed.String()
+	}
+
+	return output
+}
+
+// airAirSurveillance is the common part of the short and long air-air surveillance replies.
+type airAirSurveillance ModeS
+
+// bits reads the message bits; the position starts at 1, like in the specifications.
+func (a airAirSurveillance) bits(position uint64, count uint8) uint64 {
+	return binary.ReadBits(a, position-1, count)
+}
+
+// OnGround is the vertical status.
+func (a airAirSurveillance) OnGround() bool {
+	return a.bits(6, 1) == 1 //nolint: gomnd
+}
+
+// SensitivityLevel is the ACAS sensitivity level; 0 when ACAS is inoperative.
+func (a airAirSurveillance) SensitivityLevel() uint8 {
+	return uint8(a.bits(9, 3)) //nolint: gomnd
+}
+
+// ReplyInformation is the ACAS capability or the maximum airspeed.
+func (a airAirSurveillance) ReplyInformation() ReplyInformation {
+	return ReplyInformation(a.bits(14, 4)) //nolint: gomnd
+}
+
+// Altitude is the aircraft altitude.
+func (a airAirSurveillance) Altitude() float64 {
+	return altitudeFrom13Bits(uint16(a.bits(20, 13))) //nolint: gomnd
+}
+
+// ShortAirAirSurveillance is the short air-air surveillance reply (0).
+type ShortAirAirSurveillance struct {
+	ShortMessage
+}
+
+func (s ShortAirAirSurveillance) surveillance() airAirSurveillance {
+	return airAirSurveillance(s.ShortMessage.ModeS)
+}
+
+// OnGround is the vertical status.
+func (s ShortAirAirSurveillance) OnGround() bool {
+	return s.surveillance().OnGround()
+}
+
+// CrossLink is the cross-link capability: the transponder supports the DF16 replies to UF0 interrogations.
+func (s ShortAirAirSurveillance) CrossLink() bool {
+	return s.surveillance().bits(7, 1) == 1 //nolint: gomnd
+}
+
+// SensitivityLevel is the ACAS sensitivity level; 0 when ACAS is inoperative.
+func (s ShortAirAirSurveillance) SensitivityLevel() uint8 {
+	return s.surveillance().SensitivityLevel()
+}
+
+// ReplyInformation is the ACAS capability or the maximum airspeed.
+func (s ShortAirAirSurveillance) ReplyInformation() ReplyInformation {
+	return s.surveillance().ReplyInformation()
+}
+
+// Altitude is the aircraft altitude.
+func (s ShortAirAirSurveillance) Altitude() float64 {
+	return s.surveillance().Altitude()
+}
+
+// LongAirAirSurveillance is the long air-air surveillance reply (16).
+type LongAirAirSurveillance struct {
+	LongMessage
+}
+
+func (l LongAirAirSurveillance) surveillance() airAirSurveillance {
+	return airAirSurveillance(l.LongMessage.ModeS)
+}
+
+// OnGround is the vertical status.
+func (l LongAirAirSurveillance) OnGround() bool {
+	return l.surveillance().OnGround()
+}
+
+// SensitivityLevel is the ACAS sensitivity level; 0 when ACAS is inoperative.
+func (l LongAirAirSurveillance) SensitivityLevel() uint8 {
+	return l.surveillance().SensitivityLevel()
+}
+
+// ReplyInformation is the ACAS capability or the maximum airspeed.
+func (l LongAirAirSurveillance) ReplyInformation() ReplyInformation {
+	return l.surveillance().ReplyInformation()
+}
+
+// Altitude is the aircraft altitude.
+func (l LongAirAirSurveillance) Altitude() float64 {
+	return l.surveillance().Altitude()
+}
+
+// ResolutionAdvisory is the resolution advisory report of the MV field.
+func (l LongAirAirSurveillance) ResolutionAdvisory() (ResolutionAdvisory, error) {
+	message := l.LongMessage.ModeS[4:11]
+
+	if message[0] != resolutionAdvisoryReport {
+		return ResolutionAdvisory{}, ErrUnsupportedFormat
+	}
+
+	return resolutionAdvisory(message, 8), nil //nolint: gomnd
+}
@@ -0,0 +1,75 @@
+package model_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortAirAirSurveillance(t *testing.T) {
+	t.Parallel()
+
+	dataByte, err := hex.DecodeString("02E18C837668DA")
+	require.NoError(t, err)
+
+	assert.Equal(t, model.ICAOAddr(0x40621D), model.ModeS(dataByte).IcaoAddrChecksum())
+
+	surveillance := model.ShortAirAirSurveillance{ShortMessage: model.ShortMessage{ModeS: dataByte}}
+
+	assert.False(t, surveillance.OnGround())
+	assert.True(t, surveillance.CrossLink())
+	assert.Equal(t, uint8(7), surveillance.SensitivityLevel())
+	assert.Equal(t, model.ReplyInformationVertical, surveillance.ReplyInformation())
+	assert.False(t, surveillance.ReplyInformation().IsAirspeed())
+	assert.InDelta(t, 38000.0, surveillance.Altitude(), 0.1)
+}
+
+func TestLongAirAirSurveillance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resolution advisory", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("80E18C8330840006AF37BCA267AB")
+		require.NoError(t, err)
+
+		surveillance := model.LongAirAirSurveillance{LongMessage: model.LongMessage{ModeS: dataByte}}
+
+		assert.Equal(t, uint8(7), surveillance.SensitivityLevel())
+		assert.InDelta(t, 38000.0, surveillance.Altitude(), 0.1)
+
+		advisory, err := surveillance.ResolutionAdvisory()
+		require.NoError(t, err)
+
+		assert.Equal(t, uint16(0x2100), advisory.ActiveAdvisories)
+		assert.True(t, advisory.Active())
+		require.NotNil(t, advisory.ThreatAddress)
+		assert.Equal(t, model.ICAOAddr(0xABCDEF), *advisory.ThreatAddress)
+	})
+
+	t.Run("terminated", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("80E18C8330000026AF37BC98B6D1")
+		require.NoError(t, err)
+
+		advisory, err := model.LongAirAirSurveillance{LongMessage: model.LongMessage{ModeS: dataByte}}.ResolutionAdvisory()
+		require.NoError(t, err)
+
+		assert.True(t, advisory.Terminated)
+		assert.False(t, advisory.Active())
+	})
+
+	t.Run("no report", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("80E18C8320000026AF37BC98B6D1")
+		require.NoError(t, err)
+
+		_, err = model.LongAirAirSurveillance{LongMessage: model.LongMessage{ModeS: dataByte}}.ResolutionAdvisory()
+		require.ErrorIs(t, err, model.ErrUnsupportedFormat)
+	})
+}
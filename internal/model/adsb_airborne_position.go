@@ -26,27 +26,13 @@ func (p AirbornePosition) DecodePosition(other AirbornePosition) (*Position, err
 
 // Altitude is the aircraft altitude.
 func (p AirbornePosition) Altitude() float64 {
-	message := p.Message()
-
 	typeCode := p.TypeCode()
 
-	encodedAltitude := (uint16(message[1]&0x7f) << 5) + uint16((message[2]&0xf8)>>3) //nolint: gomnd
+	encodedAltitude := p.EncodedAltitude()
 
 	// barometric Altitude
 	if typeCode > 8 && typeCode < 19 {
-		Qbit := encodedAltitude & 0x10 //nolint: gomnd
-
-		encodedAltitude = encodedAltitude&0x0f + ((encodedAltitude & 0xff0) >> 1) //nolint: gomnd
-
-		if Qbit == 1 {
-			return float64(encodedAltitude)*25 - 1000 //nolint: gomnd
-		}
-
-		// In the case where the altitude is higher than 50175 feet,
-		// a 100 feet increment is used. In this situation, the Q bit is set to 0,
-		// and the rest of the bits are encoded using Gray code [Doran 2007].
-		// Not yet implemented due to lack of samples (for testing purpose).
-		return -1
+		return altitudeFrom12Bits(encodedAltitude)
 	}
 
 	// GNSS altitude (in meters)
@@ -51,7 +51,39 @@ func TestAirbornePosition(t *testing.T) {
 		require.NotNil(t, pos)
 
 		assert.InDelta(t, 52.26578017412606, pos.Latitude, 0.0000000000001)
-		assert.InDelta(t, 3.72599833720439, pos.Longitude, 0.0000000000001)
+		assert.InDelta(t, 3.93891252790178, pos.Longitude, 0.0000000000001)
+	})
+
+	t.Run("local position", func(t *testing.T) {
+		t.Parallel()
+
+		current := airbornPosition(t, "8D40621D58C382D690C8AC2863A7")
+
+		pos := model.DecodeLocalPosition(current, model.Position{Latitude: 51.5, Longitude: 4.5}, false)
+		require.NotNil(t, pos)
+
+		assert.InDelta(t, 52.25720214843750, pos.Latitude, 0.0000000000001)
+		assert.InDelta(t, 3.91937255859375, pos.Longitude, 0.0000000000001)
+	})
+
+	t.Run("receiver range", func(t *testing.T) {
+		t.Parallel()
+
+		current := airbornPosition(t, "8D40621D58C382D690C8AC2863A7")
+
+		receiver := model.Receiver{
+			Position: model.Position{Latitude: 51.5, Longitude: 4.5},
+			MaxRange: 200000,
+		}
+
+		pos, err := receiver.DecodePosition(current, false)
+		require.NoError(t, err)
+		assert.InDelta(t, 52.25720214843750, pos.Latitude, 0.0000000000001)
+
+		receiver.MaxRange = 50000
+
+		_, err = receiver.DecodePosition(current, false)
+		require.ErrorIs(t, err, model.ErrOutOfRange)
 	})
 
 	t.Run("position wrong frames", func(t *testing.T) {
@@ -123,6 +155,13 @@ func TestAltitude(t *testing.T) {
 
 	current := airbornPosition(t, "8D40621D58C382D690C8AC2863A7")
 
-	alt := current.Altitude()
-	assert.EqualValues(t, float64(-1), alt) // TODO: fix this test
+	assert.InDelta(t, 38000.0, current.Altitude(), 0.1)
+
+	// Gillham altitude, Q bit unset.
+	gillham := airbornPosition(t, "8D40621D58C382D690C8AC2863A7")
+	gillham.ModeS = append(model.ModeS{}, gillham.ModeS...)
+	gillham.ModeS[5] = 0x66
+	gillham.ModeS[6] = 0x13
+
+	assert.InDelta(t, 35000.0, gillham.Altitude(), 0.1)
 }
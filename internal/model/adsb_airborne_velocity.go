@@ -80,18 +80,28 @@ func (v AirborneVelocity) IsTrueAirSpeed() bool {
 	return false
 }
 
-// VerticalRate is the vertival speed.
-func (v AirborneVelocity) VerticalRate() int64 {
-	absoluteRate := ((uint32(v.Message()[4]&0x07) << 6) + (uint32(v.Message()[5]&0xfc) >> 2) - 1) * 64 //nolint: gomnd
+// VerticalRate is the vertical speed; false when not available.
+func (v AirborneVelocity) VerticalRate() (int64, bool) {
+	encoded := (uint32(v.Message()[4]&0x07) << 6) + (uint32(v.Message()[5]&0xfc) >> 2) //nolint: gomnd
+	if encoded == 0 {
+		return 0, false
+	}
+
+	absoluteRate := (encoded - 1) * 64 //nolint: gomnd
 
 	if v.Message()[4]&0x08 != 0 {
-		return -int64(absoluteRate)
+		return -int64(absoluteRate), true
 	}
 
-	return int64(absoluteRate)
+	return int64(absoluteRate), true
+}
+
+// DeltaBarometricAvailable checks if the GNSS and barometric altitudes difference is available.
+func (v AirborneVelocity) DeltaBarometricAvailable() bool {
+	return v.Message()[6]&0x7f != 0 //nolint: gomnd
 }
 
-// DeltaBarometric is the GNSS and barometric altitudes difference.
+// DeltaBarometric is the GNSS altitude minus the barometric altitude, in feet.
 func (v AirborneVelocity) DeltaBarometric() int16 {
 	data := v.Message()[6] & 0x7f //nolint: gomnd
 	if data == 0 {
@@ -107,71 +117,110 @@ func (v AirborneVelocity) DeltaBarometric() int16 {
 	return absoluteDelta
 }
 
-func (v AirborneVelocity) groundSpeedVector(factor int16) (float64, float64) {
-	dew, dns, vew, vns := v.subTypeFields()
+// IsSupersonic checks if the speeds are encoded with the supersonic scale (subtypes 2 and 4).
+func (v AirborneVelocity) IsSupersonic() bool {
+	subType := v.subType()
 
-	speedX := vew - 1
-	speedY := vns - 1
+	return subType == 2 || subType == 4
+}
 
-	if dew {
-		speedX = -factor * speedX
+// speedFactor is the speed resolution in knots.
+func (v AirborneVelocity) speedFactor() float64 {
+	if v.IsSupersonic() {
+		return 4 //nolint: gomnd
 	}
 
-	if dns {
-		speedY = -factor * speedY
+	return 1
+}
+
+// IsGroundSpeed gives the type of the speed.
+func (v AirborneVelocity) IsGroundSpeed() bool {
+	subType := v.subType()
+	if subType == 1 || subType == 2 {
+		return true
 	}
 
-	return float64(speedX), float64(speedY)
+	return false
 }
 
-func (v AirborneVelocity) airSpeedPolarVector(factor int16) (float64, float64) {
-	headingStatusBit, _, hdg, airSpeed := v.subTypeFields()
+// IsAirSpeed checks if the message carries the air speed and the heading (subtypes 3 and 4).
+func (v AirborneVelocity) IsAirSpeed() bool {
+	subType := v.subType()
+
+	return subType == 3 || subType == 4
+}
+
+// GroundVelocity is the ground speed in knots and the true track in degrees (subtypes 1 and 2).
+func (v AirborneVelocity) GroundVelocity() (float64, float64, bool) {
+	if !v.IsGroundSpeed() {
+		return -1, -1, false
+	}
+
+	dew, dns, vew, vns := v.subTypeFields()
+	if vew == 0 || vns == 0 {
+		return -1, -1, false
+	}
+
+	speedX := v.speedFactor() * float64(vew-1)
+	speedY := v.speedFactor() * float64(vns-1)
 
-	heading := float64(-1)
+	if dew {
+		speedX = -speedX
+	}
 
-	if headingStatusBit {
-		heading = float64(360.0/1024.0) * float64(hdg) //nolint: gomnd
+	if dns {
+		speedY = -speedY
 	}
 
-	return float64(factor * (airSpeed - 1)), heading
+	return math.Sqrt(speedX*speedX + speedY*speedY), math.Mod(math.Atan2(speedX, speedY)*180.0/math.Pi+360, 360), true //nolint: gomnd,lll
 }
 
-// IsGroundSpeed gives the type of the speed.
-func (v AirborneVelocity) IsGroundSpeed() bool {
-	subType := v.subType()
-	if subType == 1 || subType == 2 {
-		return true
+// AirSpeed is the air speed in knots (subtypes 3 and 4); IsTrueAirSpeed gives its type.
+func (v AirborneVelocity) AirSpeed() (float64, bool) {
+	if !v.IsAirSpeed() {
+		return -1, false
 	}
 
-	return false
+	_, _, _, airSpeed := v.subTypeFields()
+	if airSpeed == 0 {
+		return -1, false
+	}
+
+	return v.speedFactor() * float64(airSpeed-1), true
 }
 
-// Speed is the speed value and the heading.
-func (v AirborneVelocity) Speed() (float64, float64) {
-	message := v.Message()
-	if (message[1]&0x07) == 0 && message[2] == 0 && message[3] == 0 && (message[4]&0xe0) == 0 { //nolint: gomnd
-		return -1, -1
+// MagneticHeading is the heading in degrees, relative to the magnetic north (subtypes 3 and 4).
+func (v AirborneVelocity) MagneticHeading() (float64, bool) {
+	if !v.IsAirSpeed() {
+		return -1, false
 	}
 
-	subType := v.subType()
-	switch subType {
-	case 1:
-		vx, vy := v.groundSpeedVector(1)
+	headingStatusBit, _, hdg, _ := v.subTypeFields()
+	if !headingStatusBit {
+		return -1, false
+	}
 
-		return math.Sqrt(vx*vx + vy*vy), math.Mod(math.Atan2(vx, vy)*180.0/math.Pi+360, 360) //nolint: gomnd
+	return float64(360.0/1024.0) * float64(hdg), true //nolint: gomnd
+}
 
-	case 2: //nolint: gomnd
-		vx, vy := v.groundSpeedVector(2) //nolint: gomnd
+// Speed is the speed value and the direction: the ground speed and the track for the subtypes 1 and 2,
+// the air speed and the magnetic heading for the subtypes 3 and 4; -1 when not available.
+func (v AirborneVelocity) Speed() (float64, float64) {
+	if speed, track, ok := v.GroundVelocity(); ok {
+		return speed, track
+	}
 
-		return math.Sqrt(vx*vx + vy*vy), math.Mod(math.Atan2(vx, vy)*180.0/math.Pi+360, 360) //nolint: gomnd
+	speed, ok := v.AirSpeed()
+	if !ok {
+		return -1, -1
+	}
 
-	case 3: //nolint: gomnd
-		return v.airSpeedPolarVector(1) //nolint: gomnd
-	case 4: //nolint: gomnd
-		return v.airSpeedPolarVector(4) //nolint: gomnd
+	heading, ok := v.MagneticHeading()
+	if !ok {
+		return speed, -1
 	}
 
-	return -1, -1
+	return speed, heading
 }
 
 // Message is the data byte.
@@ -69,8 +69,13 @@ func TestAirborneVelocity(t *testing.T) {
 		messageA := airborneVelocity(t, "8D485020994409940838175B284F")
 		messageB := airborneVelocity(t, "8DA05F219B06B6AF189400CBC33F")
 
-		assert.EqualValues(t, -832, messageA.VerticalRate())
-		assert.EqualValues(t, -2304, messageB.VerticalRate())
+		verticalRate, ok := messageA.VerticalRate()
+		assert.True(t, ok)
+		assert.EqualValues(t, -832, verticalRate)
+
+		verticalRate, ok = messageB.VerticalRate()
+		assert.True(t, ok)
+		assert.EqualValues(t, -2304, verticalRate)
 
 		assert.EqualValues(t, 550, messageA.DeltaBarometric())
 		assert.EqualValues(t, 0, messageB.DeltaBarometric())
@@ -92,3 +97,71 @@ func TestAirborneVelocity(t *testing.T) {
 		assert.InDelta(t, 243.98, headingB, 0.01)
 	})
 }
+
+func TestAirborneVelocitySubTypes(t *testing.T) {
+	t.Parallel()
+
+	message := func(extendedSquitter ...byte) model.AirborneVelocity {
+		data := make([]byte, 14)
+		data[0] = 0x8D
+		copy(data[4:], extendedSquitter)
+
+		return model.AirborneVelocity{ExtendedSquitter: model.ExtendedSquitter{ModeS: model.ModeS(data)}}
+	}
+
+	t.Run("supersonic ground speed", func(t *testing.T) {
+		t.Parallel()
+
+		velocity := message(0x9A, 0x00, 0x0A, 0x81, 0x60, 0x00, 0x00)
+
+		assert.True(t, velocity.IsSupersonic())
+		assert.False(t, velocity.DeltaBarometricAvailable())
+
+		_, ok := velocity.VerticalRate()
+		assert.False(t, ok)
+
+		speed, track, ok := velocity.GroundVelocity()
+		require.True(t, ok)
+		assert.InDelta(t, 53.81, speed, 0.01)
+		assert.InDelta(t, 138.01, track, 0.01)
+
+		_, ok = velocity.MagneticHeading()
+		assert.False(t, ok)
+	})
+
+	t.Run("supersonic air speed", func(t *testing.T) {
+		t.Parallel()
+
+		velocity := message(0x9C, 0x05, 0x00, 0x8C, 0xA0, 0x00, 0x00)
+
+		assert.True(t, velocity.IsSupersonic())
+		assert.True(t, velocity.IsTrueAirSpeed())
+
+		_, _, ok := velocity.GroundVelocity()
+		assert.False(t, ok)
+
+		speed, ok := velocity.AirSpeed()
+		require.True(t, ok)
+		assert.InDelta(t, 400, speed, 0.01)
+
+		heading, ok := velocity.MagneticHeading()
+		require.True(t, ok)
+		assert.InDelta(t, 90, heading, 0.01)
+	})
+
+	t.Run("not available", func(t *testing.T) {
+		t.Parallel()
+
+		velocity := message(0x9B, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00)
+
+		_, ok := velocity.AirSpeed()
+		assert.False(t, ok)
+
+		_, ok = velocity.MagneticHeading()
+		assert.False(t, ok)
+
+		speed, heading := velocity.Speed()
+		assert.InDelta(t, -1, speed, 0.01)
+		assert.InDelta(t, -1, heading, 0.01)
+	})
+}
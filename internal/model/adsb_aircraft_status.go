@@ -0,0 +1,214 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/landru29/adsb1090/internal/binary"
+)
+
+//       ┏━━━━━┓
+//       ┃ 28  ┃
+//       ┣━━━━━╇━━━━┯━━━━┯━━━━━━━━┯━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓
+//       ┃ TC  | ST | ES | Squawk |              Reserved              ┃
+//       ┠┈┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┨
+//       ┃  5  | =1 | 3  |   13   |                 32                 ┃
+//       ┗━━━━━╈━━━━╇━━━━┷┯━━━━━┯━┷━━━┯━━━━━┯━━━━━┯━━━━━━━━━━━━━━━━━━━━┫
+//             ┃ =2 | ARA | RAC | RAT | MTE | TTI |        TID         ┃
+//             ┗━━━━╅┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┨
+//                  ┃ 14  |  4  |  1  |  1  |  2  |         26         ┃
+//                  ┗━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━━━━━━━━━━━━━━━━┛
+//
+// ES: emergency state, ARA: active resolution advisories, RAC: resolution advisory complements,
+// RAT: resolution advisory terminated, MTE: multiple threat encounter, TTI: threat type indicator,
+// TID: threat identity data.
+
+const aircraftStatusName = "aircraft status"
+
+const (
+	// AircraftStatusEmergency is the subtype of the emergency/priority status.
+	AircraftStatusEmergency SubTypeCode = 1
+	// AircraftStatusResolutionAdvisory is the subtype of the TCAS resolution advisory broadcast.
+	AircraftStatusResolutionAdvisory SubTypeCode = 2
+
+	// ThreatTypeAddress is when the threat identity is a Mode S address.
+	ThreatTypeAddress ThreatType = 1
+	// ThreatTypePosition is when the threat identity is the altitude, range and bearing.
+	ThreatTypePosition ThreatType = 2
+)
+
+// Emergency is the emergency state of the aircraft.
+type Emergency uint8
+
+const (
+	// EmergencyNone is when there is no emergency.
+	EmergencyNone Emergency = 0
+	// EmergencyGeneral is the general emergency.
+	EmergencyGeneral Emergency = 1
+	// EmergencyMedical is the lifeguard/medical emergency.
+	EmergencyMedical Emergency = 2
+	// EmergencyMinimumFuel is the minimum fuel emergency.
+	EmergencyMinimumFuel Emergency = 3
+	// EmergencyNoCommunications is the communication failure.
+	EmergencyNoCommunications Emergency = 4
+	// EmergencyUnlawfulInterference is the unlawful interference.
+	EmergencyUnlawfulInterference Emergency = 5
+	// EmergencyDownedAircraft is the downed aircraft.
+	EmergencyDownedAircraft Emergency = 6
+	// EmergencyReserved is the reserved value.
+	EmergencyReserved Emergency = 7
+)
+
+// String implements the Stringer interface.
+func (e Emergency) String() string {
+	switch e {
+	case EmergencyNone:
+		return "none"
+	case EmergencyGeneral:
+		return "general"
+	case EmergencyMedical:
+		return "medical"
+	case EmergencyMinimumFuel:
+		return "minimum fuel"
+	case EmergencyNoCommunications:
+		return "no communications"
+	case EmergencyUnlawfulInterference:
+		return "unlawful interference"
+	case EmergencyDownedAircraft:
+		return "downed aircraft"
+	}
+
+	return "reserved"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e Emergency) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *Emergency) UnmarshalText(data []byte) error {
+	for value := EmergencyNone; value <= EmergencyReserved; value++ {
+		if value.String() == string(data) {
+			*e = value
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: emergency %s", ErrUnsupportedFormat, data)
+}
+
+// ThreatType is the threat type indicator of a resolution advisory.
+type ThreatType uint8
+
+// ResolutionAdvisory is the TCAS resolution advisory.
+type ResolutionAdvisory struct {
+	ActiveAdvisories uint16     `json:"activeAdvisories"` /* ARA: 14 bits */
+	Complements      uint8      `json:"complements"`      /* RAC: 4 bits */
+	Terminated       bool       `json:"terminated"`
+	MultipleThreat   bool       `json:"multipleThreat"`
+	ThreatType       ThreatType `json:"threatType"`
+	ThreatAddress    *ICAOAddr  `json:"threatAddress,omitempty"` /* Only with ThreatTypeAddress. */
+	ThreatIdentity   uint32     `json:"threatIdentity"`          /* TID: 26 bits */
+}
+
+// String implements the Stringer interface.
+func (r ResolutionAdvisory) String() string {
+	fields := []string{fmt.Sprintf("RA %04X", r.ActiveAdvisories)}
+
+	if r.Terminated {
+		fields = append(fields, "terminated")
+	}
+
+	if r.MultipleThreat {
+		fields = append(fields, "multiple threats")
+	}
+
+	if r.ThreatAddress != nil {
+		fields = append(fields, fmt.Sprintf("threat %s", r.ThreatAddress))
+	}
+
+	return strings.Join(fields, ", ")
+}
+
+// Active checks if the advisory is in progress.
+func (r ResolutionAdvisory) Active() bool {
+	return !r.Terminated && (r.ActiveAdvisories != 0 || r.Complements != 0)
+}
+
+// Equal checks if both advisories are identical.
+func (r ResolutionAdvisory) Equal(other ResolutionAdvisory) bool {
+	return r.ActiveAdvisories == other.ActiveAdvisories &&
+		r.Complements == other.Complements &&
+		r.Terminated == other.Terminated &&
+		r.MultipleThreat == other.MultipleThreat &&
+		r.ThreatType == other.ThreatType &&
+		r.ThreatIdentity == other.ThreatIdentity
+}
+
+// AircraftStatus is the aircraft status.
+type AircraftStatus struct {
+	ExtendedSquitter
+}
+
+// Name implements the Message interface.
+func (a AircraftStatus) Name() string {
+	return aircraftStatusName
+}
+
+// Message is the data byte.
+func (a AircraftStatus) Message() []byte {
+	return LongMessage(a.ExtendedSquitter).Message()
+}
+
+// Emergency is the emergency state (emergency/priority status only).
+func (a AircraftStatus) Emergency() Emergency {
+	if a.SubTypeCode() != AircraftStatusEmergency {
+		return EmergencyNone
+	}
+
+	return Emergency(a.bits(9, 3)) //nolint: gomnd
+}
+
+// Squawk is the Mode A code (emergency/priority status only).
+func (a AircraftStatus) Squawk() Squawk {
+	if a.SubTypeCode() != AircraftStatusEmergency {
+		return 0
+	}
+
+	return IdentityFrom12Bits(uint16(a.bits(12, 13))) //nolint: gomnd
+}
+
+// ResolutionAdvisory is the TCAS resolution advisory broadcast.
+func (a AircraftStatus) ResolutionAdvisory() (ResolutionAdvisory, error) {
+	if a.SubTypeCode() != AircraftStatusResolutionAdvisory {
+		return ResolutionAdvisory{}, ErrUnsupportedFormat
+	}
+
+	return resolutionAdvisory(a.Message(), 8), nil //nolint: gomnd
+}
+
+// bits reads the message bits; the position starts at 1, like in the specifications.
+func (a AircraftStatus) bits(position uint64, count uint8) uint64 {
+	return binary.ReadBits(a.Message(), position-1, count)
+}
+
+// resolutionAdvisory decodes the 54 bits of a resolution advisory, starting at the cursor (0-based).
+func resolutionAdvisory(data []byte, cursor uint64) ResolutionAdvisory {
+	output := ResolutionAdvisory{
+		ActiveAdvisories: uint16(binary.ReadBits(data, cursor, 14)),       //nolint: gomnd
+		Complements:      uint8(binary.ReadBits(data, cursor+14, 4)),      //nolint: gomnd
+		Terminated:       binary.ReadBits(data, cursor+18, 1) == 1,        //nolint: gomnd
+		MultipleThreat:   binary.ReadBits(data, cursor+19, 1) == 1,        //nolint: gomnd
+		ThreatType:       ThreatType(binary.ReadBits(data, cursor+20, 2)), //nolint: gomnd
+		ThreatIdentity:   uint32(binary.ReadBits(data, cursor+22, 26)),    //nolint: gomnd
+	}
+
+	if output.ThreatType == ThreatTypeAddress {
+		addr := ICAOAddr(output.ThreatIdentity >> 2) //nolint: gomnd
+		output.ThreatAddress = &addr
+	}
+
+	return output
+}
@@ -0,0 +1,84 @@
+package model_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAircraftStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("emergency", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("8D4CA92BE12AAA00000000BA46B4")
+		require.NoError(t, err)
+
+		require.NoError(t, model.ModeS(dataByte).CheckSum())
+
+		msg, err := model.ExtendedSquitter{ModeS: dataByte}.Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, "aircraft status", msg.Name())
+
+		status, ok := msg.(model.AircraftStatus)
+		require.True(t, ok)
+
+		assert.Equal(t, model.EmergencyGeneral, status.Emergency())
+		assert.Equal(t, model.SquawkMayday, status.Squawk())
+
+		_, err = status.ResolutionAdvisory()
+		require.ErrorIs(t, err, model.ErrUnsupportedFormat)
+	})
+
+	t.Run("resolution advisory", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("8D4CA92BE28000152103583031B6")
+		require.NoError(t, err)
+
+		require.NoError(t, model.ModeS(dataByte).CheckSum())
+
+		msg, err := model.ExtendedSquitter{ModeS: dataByte}.Decode()
+		require.NoError(t, err)
+
+		status, ok := msg.(model.AircraftStatus)
+		require.True(t, ok)
+
+		assert.Equal(t, model.EmergencyNone, status.Emergency())
+
+		advisory, err := status.ResolutionAdvisory()
+		require.NoError(t, err)
+
+		threat := model.ICAOAddr(0x4840D6)
+
+		assert.Equal(t, model.ResolutionAdvisory{
+			ActiveAdvisories: 0x2000,
+			MultipleThreat:   true,
+			ThreatType:       model.ThreatTypeAddress,
+			ThreatAddress:    &threat,
+			ThreatIdentity:   0x4840D6 << 2,
+		}, advisory)
+	})
+}
+
+func TestEmergency(t *testing.T) {
+	t.Parallel()
+
+	data, err := model.EmergencyUnlawfulInterference.MarshalText()
+	require.NoError(t, err)
+
+	assert.Equal(t, "unlawful interference", string(data))
+	assert.Equal(t, "reserved", model.EmergencyReserved.String())
+
+	var emergency model.Emergency
+
+	require.NoError(t, emergency.UnmarshalText([]byte("minimum fuel")))
+	assert.Equal(t, model.EmergencyMinimumFuel, emergency)
+
+	require.ErrorIs(t, emergency.UnmarshalText([]byte("unknown")), model.ErrUnsupportedFormat)
+}
@@ -0,0 +1,48 @@
+package model
+
+import "github.com/landru29/adsb1090/internal/binary"
+
+//       ┏━━━━┓
+//       ┃ 11 ┃  All-call reply
+//       ┣━━━━╇━━━━┯━━━━┯━━━━━━━━┓
+//       ┃ DF | CA | AA | Parity ┃
+//       ┠┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┈┈┈┈┨
+//       ┃ 5  |  3 | 24 |   24   ┃
+//       ┗━━━━┷━━━━┷━━━━┷━━━━━━━━┛
+//       1    6    9    33
+//
+// The parity is the checksum xored with the interrogator identifier (II or SI code)
+// of the interrogation; it is 0 for the acquisition squitters.
+
+const (
+	allCallReplyName = "all-call reply"
+
+	// interrogatorMask is the part of the parity overlaid with the interrogator identifier.
+	interrogatorMask = 0x7f
+)
+
+// AllCallReply is the all-call reply, and the acquisition squitter (11).
+type AllCallReply struct {
+	ShortMessage
+}
+
+// Capability is the transponder capability.
+func (a AllCallReply) Capability() TransponderCapability {
+	return TransponderCapability(a.ShortMessage.ModeS[0] & 0x07) //nolint: gomnd
+}
+
+// AnnouncedAddress is the address announced by the aircraft (AA).
+func (a AllCallReply) AnnouncedAddress() ICAOAddr {
+	return ICAOAddr(binary.ReadBits(a.ShortMessage.ModeS, 8, 24)) //nolint: gomnd
+}
+
+// InterrogatorID is the identifier of the interrogator, recovered from the parity.
+// It is 0 for the acquisition squitters.
+func (a AllCallReply) InterrogatorID() uint8 {
+	return uint8(binary.Syndrome(a.ShortMessage.ModeS) & interrogatorMask)
+}
+
+// Name implements the Squitter interface.
+func (a AllCallReply) Name() string {
+	return allCallReplyName
+}
@@ -0,0 +1,52 @@
+package model_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllCallReply(t *testing.T) {
+	t.Parallel()
+
+	for _, fixtureElt := range []struct {
+		name         string
+		frame        string
+		interrogator uint8
+	}{
+		{
+			name:  "acquisition squitter",
+			frame: "5D40621D4F94D0",
+		},
+		{
+			name:         "interrogator",
+			frame:        "5D40621D4F94C5",
+			interrogator: 0x15,
+		},
+	} {
+		fixture := fixtureElt
+
+		t.Run(fixture.name, func(t *testing.T) {
+			t.Parallel()
+
+			dataByte, err := hex.DecodeString(fixture.frame)
+			require.NoError(t, err)
+
+			squitter, err := model.ModeS(dataByte).QualifiedMessage()
+			require.NoError(t, err)
+
+			assert.Equal(t, model.ICAOAddr(0x40621D), squitter.AircraftAddress())
+
+			shortMessage, ok := squitter.(model.ShortMessage)
+			require.True(t, ok)
+
+			reply := model.AllCallReply{ShortMessage: shortMessage}
+
+			assert.Equal(t, model.TransponderCapabilityAirborne, reply.Capability())
+			assert.Equal(t, fixture.interrogator, reply.InterrogatorID())
+		})
+	}
+}
@@ -0,0 +1,693 @@
+package model
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/landru29/adsb1090/internal/binary"
+	"github.com/landru29/adsb1090/internal/errors"
+)
+
+// The MB field of the Comm-B replies (DF 20 and 21) holds the content of a transponder register (BDS).
+// The register number is not transmitted: it is inferred from the plausibility of the fields.
+//
+//       ┏━━━━━┓
+//       ┃ 2,0 ┃  Aircraft identification
+//       ┣━━━━━╇━━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━━┓
+//       ┃ BDS | C1 | C2 | C3 | C4 | C5 | C6 | C7 | C8 ┃
+//       ┠┈┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┨
+//       ┃  8  |  6 |  6 |  6 |  6 |  6 |  6 |  6 |  6 ┃
+//       ┗━━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━━┛
+//
+//       ┏━━━━━┓
+//       ┃ 4,0 ┃  Selected vertical intention
+//       ┣━━━━━╇━━━━━┯━━━━━━━━━━━━━━┯━━━━━┯━━━━━━━━━━━━━━┯━━━━━┯━━━━━━━━━━━━━┯━━━━━━━━━━━━━━━━┓
+//       ┃  S  | MCP |       S      | FMS |       S      | QNH |  Reserved   | Modes + source ┃
+//       ┠┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┨
+//       ┃  1  |  12 |       1      |  12 |       1      |  12 |      8      |        9       ┃
+//       ┗━━━━━┷━━━━━┷━━━━━━━━━━━━━━┷━━━━━┷━━━━━━━━━━━━━━┷━━━━━┷━━━━━━━━━━━━━┷━━━━━━━━━━━━━━━━┛
+//
+//       ┏━━━━━┓
+//       ┃ 5,0 ┃  Track and turn report
+//       ┣━━━━━╇━━━━━━┯━━━━━━━━━━━┯━━━━━━━━━━━━━━━━┯━━━━━━━━━━━━━━━━━┯━━━━━━━━━━━━━━━━┓
+//       ┃ S+  | Roll | S+ Track  |    S + GS      |  S+ Track rate  |     S + TAS    ┃
+//       ┠┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┨
+//       ┃  2  |  9   |   2 + 10  |    1 + 10      |     2 + 9       |     1 + 10     ┃
+//       ┗━━━━━┷━━━━━━┷━━━━━━━━━━━┷━━━━━━━━━━━━━━━━┷━━━━━━━━━━━━━━━━━┷━━━━━━━━━━━━━━━━┛
+//
+//       ┏━━━━━┓
+//       ┃ 6,0 ┃  Heading and speed report
+//       ┣━━━━━╇━━━━━━━━━┯━━━━━━━━━━━━━━┯━━━━━━━━━━━━━━┯━━━━━━━━━━━━━━━━━━┯━━━━━━━━━━━━━━━━━━━┓
+//       ┃ S+  | Heading |    S + IAS   |   S + Mach   |  S+ Baro V-rate  | S+ Inertial V-rate┃
+//       ┠┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┨
+//       ┃  2  |   10    |    1 + 10    |    1 + 10    |      2 + 9       |       2 + 9       ┃
+//       ┗━━━━━┷━━━━━━━━━┷━━━━━━━━━━━━━━┷━━━━━━━━━━━━━━┷━━━━━━━━━━━━━━━━━━┷━━━━━━━━━━━━━━━━━━━┛
+//
+// S: status bit (the following field is valid), S+: status and sign bits.
+
+const (
+	// ErrUnknownRegister is when no Comm-B register matches the MB field.
+	ErrUnknownRegister errors.Error = "unknown Comm-B register"
+	// ErrAmbiguousRegister is when several Comm-B registers match the MB field.
+	ErrAmbiguousRegister errors.Error = "ambiguous Comm-B register"
+
+	commBSize = 7
+
+	// headerScore is the score of the registers starting with their own number.
+	headerScore = 8
+
+	maxSelectedAltitude = 50000
+	maxRollAngle        = 50
+	maxGroundSpeed      = 600
+	maxTrueAirSpeed     = 500
+	maxSpeedDifference  = 200
+	maxIndicatedSpeed   = 500
+	maxMach             = 1
+	maxVerticalRate     = 6000
+	maxWindSpeed        = 250
+	minTemperature      = -80
+	maxTemperature      = 60
+
+	// speedOfSoundKnots is the speed of sound at sea level.
+	speedOfSoundKnots = 661.47
+	// minMachToIASRatio is the ratio IAS / (Mach * speed of sound) at the highest flight levels.
+	minMachToIASRatio = 0.3
+	// maxMachToIASRatio is the ratio IAS / (Mach * speed of sound) at sea level, with margin.
+	maxMachToIASRatio = 1.05
+)
+
+// BDS is a Comm-B register number (ie: 0x40 for the register 4,0).
+type BDS uint8
+
+const (
+	// BDS10 is the data link capability report.
+	BDS10 BDS = 0x10
+	// BDS17 is the common usage GICB capability report.
+	BDS17 BDS = 0x17
+	// BDS20 is the aircraft identification.
+	BDS20 BDS = 0x20
+	// BDS30 is the ACAS active resolution advisory.
+	BDS30 BDS = 0x30
+	// BDS40 is the selected vertical intention.
+	BDS40 BDS = 0x40
+	// BDS44 is the meteorological routine air report.
+	BDS44 BDS = 0x44
+	// BDS45 is the meteorological hazard report.
+	BDS45 BDS = 0x45
+	// BDS50 is the track and turn report.
+	BDS50 BDS = 0x50
+	// BDS60 is the heading and speed report.
+	BDS60 BDS = 0x60
+)
+
+// String implements the Stringer interface.
+func (b BDS) String() string {
+	return fmt.Sprintf("%X,%X", uint8(b)>>4, uint8(b)&0x0f) //nolint: gomnd
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (b BDS) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// gicbCapabilities are the registers of the 1,7 capability report, in the bit order.
+var gicbCapabilities = []BDS{ //nolint: gochecknoglobals
+	0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x20, 0x21, 0x40, 0x41, 0x42, 0x43,
+	0x44, 0x45, 0x48, 0x50, 0x51, 0x52, 0x53, 0x54, 0x55, 0x56, 0x5f, 0x60,
+}
+
+// HazardLevel is the level of a meteorological hazard.
+type HazardLevel uint8
+
+const (
+	// HazardNil is when there is no hazard.
+	HazardNil HazardLevel = 0
+	// HazardLight is the light hazard.
+	HazardLight HazardLevel = 1
+	// HazardModerate is the moderate hazard.
+	HazardModerate HazardLevel = 2
+	// HazardSevere is the severe hazard.
+	HazardSevere HazardLevel = 3
+)
+
+// CommBData is the content of a Comm-B register; only the fields of the register are set.
+type CommBData struct {
+	Register             BDS                 `json:"register"`
+	Capabilities         []BDS               `json:"capabilities,omitempty"`       /* 1,7 */
+	Callsign             string              `json:"callsign,omitempty"`           /* 2,0 */
+	ResolutionAdvisory   *ResolutionAdvisory `json:"resolutionAdvisory,omitempty"` /* 3,0 */
+	SelectedAltitudeMCP  *int64              `json:"selectedAltitudeMcp,omitempty"`
+	SelectedAltitudeFMS  *int64              `json:"selectedAltitudeFms,omitempty"`
+	BaroSetting          *float64            `json:"baroSetting,omitempty"`
+	WindSpeed            *float64            `json:"windSpeed,omitempty"`     /* knots */
+	WindDirection        *float64            `json:"windDirection,omitempty"` /* degrees */
+	StaticAirTemperature *float64            `json:"staticAirTemperature,omitempty"`
+	StaticPressure       *float64            `json:"staticPressure,omitempty"` /* hPa */
+	Humidity             *float64            `json:"humidity,omitempty"`       /* percent */
+	Turbulence           *HazardLevel        `json:"turbulence,omitempty"`
+	WindShear            *HazardLevel        `json:"windShear,omitempty"`
+	Microburst           *HazardLevel        `json:"microburst,omitempty"`
+	Icing                *HazardLevel        `json:"icing,omitempty"`
+	WakeVortex           *HazardLevel        `json:"wakeVortex,omitempty"`
+	RadioHeight          *int64              `json:"radioHeight,omitempty"` /* feet */
+	RollAngle            *float64            `json:"rollAngle,omitempty"`   /* degrees, positive to the right */
+	TrueTrack            *float64            `json:"trueTrack,omitempty"`
+	GroundSpeed          *float64            `json:"groundSpeed,omitempty"`
+	TrackRate            *float64            `json:"trackRate,omitempty"` /* degrees per second */
+	TrueAirSpeed         *float64            `json:"trueAirSpeed,omitempty"`
+	MagneticHeading      *float64            `json:"magneticHeading,omitempty"`
+	IndicatedAirSpeed    *float64            `json:"indicatedAirSpeed,omitempty"`
+	Mach                 *float64            `json:"mach,omitempty"`
+	BaroVerticalRate     *int64              `json:"baroVerticalRate,omitempty"`     /* feet per minute */
+	InertialVerticalRate *int64              `json:"inertialVerticalRate,omitempty"` /* feet per minute */
+}
+
+// CommB is the 56 bits MB field of a Comm-B reply.
+type CommB []byte
+
+// registerDecoder decodes a register; the score is the amount of information found, and ok is false
+// when the MB field cannot be this register.
+type registerDecoder func(CommB) (data CommBData, score int, ok bool)
+
+// registerDecoders are all the inferred registers.
+var registerDecoders = []struct { //nolint: gochecknoglobals
+	register BDS
+	decode   registerDecoder
+}{
+	{register: BDS10, decode: CommB.bds10},
+	{register: BDS17, decode: CommB.bds17},
+	{register: BDS20, decode: CommB.bds20},
+	{register: BDS30, decode: CommB.bds30},
+	{register: BDS40, decode: CommB.bds40},
+	{register: BDS44, decode: CommB.bds44},
+	{register: BDS45, decode: CommB.bds45},
+	{register: BDS50, decode: CommB.bds50},
+	{register: BDS60, decode: CommB.bds60},
+}
+
+// Candidates lists the registers that are plausible for this MB field.
+func (c CommB) Candidates() []BDS {
+	output := []BDS{}
+
+	if len(c) != commBSize {
+		return output
+	}
+
+	for _, candidate := range registerDecoders {
+		if _, score, ok := candidate.decode(c); ok && score > 0 {
+			output = append(output, candidate.register)
+		}
+	}
+
+	return output
+}
+
+// Decode infers the register with the best score, and decodes it.
+func (c CommB) Decode() (CommBData, error) {
+	if len(c) != commBSize {
+		return CommBData{}, ErrUnknownRegister
+	}
+
+	var (
+		best      CommBData
+		bestScore int
+		ambiguous bool
+	)
+
+	for _, candidate := range registerDecoders {
+		data, score, ok := candidate.decode(c)
+		if !ok || score <= 0 || score < bestScore {
+			continue
+		}
+
+		ambiguous = score == bestScore
+		data.Register = candidate.register
+		best = data
+		bestScore = score
+	}
+
+	if bestScore == 0 {
+		return CommBData{}, ErrUnknownRegister
+	}
+
+	if ambiguous {
+		return CommBData{}, fmt.Errorf("%w: %v", ErrAmbiguousRegister, c.Candidates())
+	}
+
+	return best, nil
+}
+
+// bds10 is the data link capability report.
+func (c CommB) bds10() (CommBData, int, bool) {
+	if BDS(c.bits(1, 8)) != BDS10 || c.bits(10, 5) != 0 { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	return CommBData{}, headerScore, true
+}
+
+// bds17 is the common usage GICB capability report.
+func (c CommB) bds17() (CommBData, int, bool) {
+	if c.bits(25, 32) != 0 || c.bits(7, 1) != 1 { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	data := CommBData{}
+
+	for idx, register := range gicbCapabilities {
+		if c.bits(uint64(idx+1), 1) == 1 {
+			data.Capabilities = append(data.Capabilities, register)
+		}
+	}
+
+	return data, 1, true
+}
+
+// bds20 is the aircraft identification.
+func (c CommB) bds20() (CommBData, int, bool) {
+	if BDS(c.bits(1, 8)) != BDS20 { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	letters := make([]byte, 8) //nolint: gomnd
+
+	for idx := range letters {
+		letters[idx] = asciiTable[c.bits(uint64(9+6*idx), 6)] //nolint: gomnd
+		if letters[idx] == '#' {
+			return CommBData{}, 0, false
+		}
+	}
+
+	return CommBData{Callsign: string(letters)}, headerScore, true
+}
+
+// bds30 is the ACAS active resolution advisory.
+func (c CommB) bds30() (CommBData, int, bool) {
+	if BDS(c.bits(1, 8)) != BDS30 { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	advisory := resolutionAdvisory(c, 8) //nolint: gomnd
+	if advisory.ThreatType == 3 {        //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	return CommBData{ResolutionAdvisory: &advisory}, headerScore, true
+}
+
+// bds40 is the selected vertical intention.
+func (c CommB) bds40() (CommBData, int, bool) { //nolint: cyclop
+	if c.bits(40, 8) != 0 || c.bits(52, 2) != 0 { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	data := CommBData{}
+	score := 0
+
+	mcp, present, ok := c.field(1, 12) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		altitude := int64(mcp) * 16 //nolint: gomnd
+		if altitude > maxSelectedAltitude {
+			return CommBData{}, 0, false
+		}
+
+		data.SelectedAltitudeMCP = &altitude
+		score++
+	}
+
+	fms, present, ok := c.field(14, 12) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		altitude := int64(fms) * 16 //nolint: gomnd
+		if altitude > maxSelectedAltitude {
+			return CommBData{}, 0, false
+		}
+
+		data.SelectedAltitudeFMS = &altitude
+		score++
+	}
+
+	baro, present, ok := c.field(27, 12) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		setting := baroSettingOrigin + float64(baro)*0.1 //nolint: gomnd
+		data.BaroSetting = &setting
+		score++
+	}
+
+	if _, _, ok := c.field(48, 3); !ok { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	if _, _, ok := c.field(54, 2); !ok { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	return data, score, true
+}
+
+// bds44 is the meteorological routine air report.
+func (c CommB) bds44() (CommBData, int, bool) { //nolint: cyclop
+	if c.bits(1, 4) > 4 { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	data := CommBData{}
+	score := 0
+
+	wind, present, ok := c.field(5, 18) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		speed := float64(wind >> 9)                      //nolint: gomnd
+		direction := float64(wind&0x1ff) * 180.0 / 256.0 //nolint: gomnd
+
+		if speed > maxWindSpeed {
+			return CommBData{}, 0, false
+		}
+
+		data.WindSpeed = &speed
+		data.WindDirection = &direction
+		score++
+	}
+
+	temperature := float64(signed(c.bits(25, 10), c.bits(24, 1) == 1, 10)) * 0.25 //nolint: gomnd
+	if temperature < minTemperature || temperature > maxTemperature {
+		return CommBData{}, 0, false
+	}
+
+	if temperature != 0 {
+		data.StaticAirTemperature = &temperature
+		score++
+	}
+
+	pressure, present, ok := c.field(35, 11) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(pressure)
+		data.StaticPressure = &value
+		score++
+	}
+
+	turbulence, present, ok := c.field(47, 2) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		level := HazardLevel(turbulence)
+		data.Turbulence = &level
+		score++
+	}
+
+	humidity, present, ok := c.field(50, 6) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(humidity) * 100.0 / 64.0 //nolint: gomnd
+		data.Humidity = &value
+		score++
+	}
+
+	return data, score, true
+}
+
+// bds45 is the meteorological hazard report.
+func (c CommB) bds45() (CommBData, int, bool) { //nolint: cyclop
+	if c.bits(52, 5) != 0 { //nolint: gomnd
+		return CommBData{}, 0, false
+	}
+
+	data := CommBData{}
+	score := 0
+
+	for _, hazard := range []struct {
+		status uint64
+		target **HazardLevel
+	}{
+		{status: 1, target: &data.Turbulence},
+		{status: 4, target: &data.WindShear},   //nolint: gomnd
+		{status: 7, target: &data.Microburst},  //nolint: gomnd
+		{status: 10, target: &data.Icing},      //nolint: gomnd
+		{status: 13, target: &data.WakeVortex}, //nolint: gomnd
+	} {
+		value, present, ok := c.field(hazard.status, 2) //nolint: gomnd
+		if !ok {
+			return CommBData{}, 0, false
+		}
+
+		if present {
+			level := HazardLevel(value)
+			*hazard.target = &level
+			score++
+		}
+	}
+
+	temperature, present, ok := c.field(16, 10) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(signed(temperature&0x1ff, temperature>>9 == 1, 9)) * 0.25 //nolint: gomnd
+		if value < minTemperature || value > maxTemperature {
+			return CommBData{}, 0, false
+		}
+
+		data.StaticAirTemperature = &value
+		score++
+	}
+
+	pressure, present, ok := c.field(27, 11) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(pressure)
+		data.StaticPressure = &value
+		score++
+	}
+
+	height, present, ok := c.field(39, 12) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := int64(height) * 16 //nolint: gomnd
+		data.RadioHeight = &value
+		score++
+	}
+
+	return data, score, true
+}
+
+// bds50 is the track and turn report.
+func (c CommB) bds50() (CommBData, int, bool) { //nolint: cyclop
+	data := CommBData{}
+	score := 0
+
+	roll, present, ok := c.field(1, 10) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(signed(roll&0x1ff, roll>>9 == 1, 9)) * 45.0 / 256.0 //nolint: gomnd
+		if math.Abs(value) > maxRollAngle {
+			return CommBData{}, 0, false
+		}
+
+		data.RollAngle = &value
+		score++
+	}
+
+	track, present, ok := c.field(12, 11) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(signed(track&0x3ff, track>>10 == 1, 10)) * 90.0 / 512.0 //nolint: gomnd
+		if value < 0 {
+			value += 360
+		}
+
+		data.TrueTrack = &value
+		score++
+	}
+
+	groundSpeed, present, ok := c.field(24, 10) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(groundSpeed) * 2 //nolint: gomnd
+		if value > maxGroundSpeed {
+			return CommBData{}, 0, false
+		}
+
+		data.GroundSpeed = &value
+		score++
+	}
+
+	trackRate, present, ok := c.field(35, 10) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(signed(trackRate&0x1ff, trackRate>>9 == 1, 9)) * 8.0 / 256.0 //nolint: gomnd
+		data.TrackRate = &value
+		score++
+	}
+
+	trueAirSpeed, present, ok := c.field(46, 10) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(trueAirSpeed) * 2 //nolint: gomnd
+		if value > maxTrueAirSpeed {
+			return CommBData{}, 0, false
+		}
+
+		data.TrueAirSpeed = &value
+		score++
+	}
+
+	if data.GroundSpeed != nil && data.TrueAirSpeed != nil &&
+		math.Abs(*data.GroundSpeed-*data.TrueAirSpeed) > maxSpeedDifference {
+		return CommBData{}, 0, false
+	}
+
+	return data, score, true
+}
+
+// bds60 is the heading and speed report.
+func (c CommB) bds60() (CommBData, int, bool) { //nolint: cyclop
+	data := CommBData{}
+	score := 0
+
+	heading, present, ok := c.field(1, 11) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(signed(heading&0x3ff, heading>>10 == 1, 10)) * 90.0 / 512.0 //nolint: gomnd
+		if value < 0 {
+			value += 360
+		}
+
+		data.MagneticHeading = &value
+		score++
+	}
+
+	indicatedAirSpeed, present, ok := c.field(13, 10) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(indicatedAirSpeed)
+		if value > maxIndicatedSpeed {
+			return CommBData{}, 0, false
+		}
+
+		data.IndicatedAirSpeed = &value
+		score++
+	}
+
+	mach, present, ok := c.field(24, 10) //nolint: gomnd
+	if !ok {
+		return CommBData{}, 0, false
+	}
+
+	if present {
+		value := float64(mach) * 2.048 / 512.0 //nolint: gomnd
+		if value > maxMach {
+			return CommBData{}, 0, false
+		}
+
+		data.Mach = &value
+		score++
+	}
+
+	if data.IndicatedAirSpeed != nil && data.Mach != nil {
+		ratio := *data.IndicatedAirSpeed / (*data.Mach * speedOfSoundKnots)
+		if ratio < minMachToIASRatio || ratio > maxMachToIASRatio {
+			return CommBData{}, 0, false
+		}
+	}
+
+	for _, rate := range []struct {
+		status uint64
+		target **int64
+	}{
+		{status: 35, target: &data.BaroVerticalRate},     //nolint: gomnd
+		{status: 46, target: &data.InertialVerticalRate}, //nolint: gomnd
+	} {
+		value, present, ok := c.field(rate.status, 10) //nolint: gomnd
+		if !ok {
+			return CommBData{}, 0, false
+		}
+
+		if present {
+			verticalRate := signed(value&0x1ff, value>>9 == 1, 9) * 32 //nolint: gomnd
+			if verticalRate > maxVerticalRate || verticalRate < -maxVerticalRate {
+				return CommBData{}, 0, false
+			}
+
+			*rate.target = &verticalRate
+			score++
+		}
+	}
+
+	return data, score, true
+}
+
+// field reads the value following a status bit; ok is false when the status is not set but the value is.
+func (c CommB) field(status uint64, count uint8) (uint64, bool, bool) {
+	value := c.bits(status+1, count)
+
+	if c.bits(status, 1) == 0 {
+		return 0, false, value == 0
+	}
+
+	return value, true, true
+}
+
+// bits reads the MB bits; the position starts at 1, like in the specifications.
+func (c CommB) bits(position uint64, count uint8) uint64 {
+	return binary.ReadBits(c, position-1, count)
+}
+
+// signed is the two's complement value, with the sign bit apart.
+func signed(value uint64, negative bool, count uint8) int64 {
+	if negative {
+		return int64(value) - int64(1)<<count
+	}
+
+	return int64(value)
+}
@@ -52,6 +52,11 @@ func (c CommBReplyWithAltitude) Altitude() float64 { //nolint: cyclop
 	return altitudeFrom13Bits(uint16(binary.ReadBits(c.LongMessage.ModeS, 19, 13))) //nolint: gomnd
 }
 
+// CommB is the MB field.
+func (c CommBReplyWithAltitude) CommB() CommB {
+	return CommB(c.LongMessage.ModeS[4:11])
+}
+
 // CommBReplyWithIdentification is the aircraft identification (21).
 type CommBReplyWithIdentification struct {
 	LongMessage
@@ -66,3 +71,8 @@ func (c CommBReplyWithIdentification) FlightStatus() FlightStatus {
 func (c CommBReplyWithIdentification) Identity() Squawk {
 	return IdentityFrom12Bits(uint16(binary.ReadBits(c.LongMessage.ModeS, 19, 13))) //nolint: gomnd
 }
+
+// CommB is the MB field.
+func (c CommBReplyWithIdentification) CommB() CommB {
+	return CommB(c.LongMessage.ModeS[4:11])
+}
@@ -1 +1,119 @@
 package model_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func commB(t *testing.T, frame string) model.CommB {
+	t.Helper()
+
+	dataByte, err := hex.DecodeString(frame)
+	require.NoError(t, err)
+
+	return model.CommBReplyWithAltitude{LongMessage: model.LongMessage{ModeS: dataByte}}.CommB()
+}
+
+func TestCommB(t *testing.T) {
+	t.Parallel()
+
+	t.Run("aircraft identification", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := commB(t, "A000083E202CC371C31DE0AA1CCF").Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, model.BDS20, data.Register)
+		assert.Equal(t, "KLM1017 ", data.Callsign)
+	})
+
+	t.Run("selected vertical intention", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := commB(t, "A000029C85E42F313000007047D3").Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, model.BDS40, data.Register)
+		require.NotNil(t, data.SelectedAltitudeMCP)
+		assert.Equal(t, int64(3008), *data.SelectedAltitudeMCP)
+		require.NotNil(t, data.SelectedAltitudeFMS)
+		assert.Equal(t, int64(3008), *data.SelectedAltitudeFMS)
+		require.NotNil(t, data.BaroSetting)
+		assert.InDelta(t, 1020.0, *data.BaroSetting, 0.01)
+	})
+
+	t.Run("meteorological routine air report", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := commB(t, "A0001692185BD5CF400000DFC696").Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, model.BDS44, data.Register)
+		require.NotNil(t, data.WindSpeed)
+		assert.InDelta(t, 22.0, *data.WindSpeed, 0.01)
+		require.NotNil(t, data.WindDirection)
+		assert.InDelta(t, 344.5, *data.WindDirection, 0.1)
+		require.NotNil(t, data.StaticAirTemperature)
+		assert.InDelta(t, -48.75, *data.StaticAirTemperature, 0.01)
+	})
+
+	t.Run("track and turn report", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := commB(t, "A000139381951536E024D4CCF6B5").Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, model.BDS50, data.Register)
+		require.NotNil(t, data.RollAngle)
+		assert.InDelta(t, 2.1, *data.RollAngle, 0.01)
+		require.NotNil(t, data.TrueTrack)
+		assert.InDelta(t, 114.258, *data.TrueTrack, 0.001)
+		require.NotNil(t, data.GroundSpeed)
+		assert.InDelta(t, 438.0, *data.GroundSpeed, 0.01)
+		require.NotNil(t, data.TrackRate)
+		assert.InDelta(t, 0.125, *data.TrackRate, 0.001)
+		require.NotNil(t, data.TrueAirSpeed)
+		assert.InDelta(t, 424.0, *data.TrueAirSpeed, 0.01)
+	})
+
+	t.Run("heading and speed report", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := commB(t, "A00004128F39F91A7E27C46ADC21").Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, model.BDS60, data.Register)
+		require.NotNil(t, data.MagneticHeading)
+		assert.InDelta(t, 42.715, *data.MagneticHeading, 0.001)
+		require.NotNil(t, data.IndicatedAirSpeed)
+		assert.InDelta(t, 252.0, *data.IndicatedAirSpeed, 0.01)
+		require.NotNil(t, data.Mach)
+		assert.InDelta(t, 0.42, *data.Mach, 0.001)
+		require.NotNil(t, data.BaroVerticalRate)
+		assert.Equal(t, int64(-1920), *data.BaroVerticalRate)
+		require.NotNil(t, data.InertialVerticalRate)
+		assert.Equal(t, int64(-1920), *data.InertialVerticalRate)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := commB(t, "A000000000000000000000000000").Decode()
+		require.ErrorIs(t, err, model.ErrUnknownRegister)
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		t.Parallel()
+
+		mb := commB(t, "A000000080200000000000000000")
+
+		assert.Greater(t, len(mb.Candidates()), 1)
+
+		_, err := mb.Decode()
+		require.ErrorIs(t, err, model.ErrAmbiguousRegister)
+	})
+}
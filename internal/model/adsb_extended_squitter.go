@@ -1,5 +1,7 @@
 package model
 
+import "github.com/landru29/adsb1090/internal/binary"
+
 // ┏━━━━━┯━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┯━━━━━━━━┓
 // ┃ DF  |                      Extended squitter                      | Parity ┃
 // ┠┈┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┼┈┈┈┈┈┈┈┈┨
@@ -56,12 +58,48 @@ package model
 // ┃ TC   | Type Code                   |   5  ┃
 // ┃ ICAO | Aircraft unique identifier  |  24  ┃
 // ┗━━━━━━┷━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┷━━━━━━┛
+//
+// In the DF18 messages, CA is the control field (CF):
+// ┏━━━━┯━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓
+// ┃ CF | Description                                            ┃
+// ┣━━━━┿━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┫
+// ┃ 0  | ADS-B, non-transponder device, ICAO address           ┃
+// ┃ 1  | ADS-B, non-transponder device, non-ICAO address       ┃
+// ┃ 2  | Fine TIS-B, address type from the IMF bit             ┃
+// ┃ 3  | Coarse TIS-B, address type from the IMF bit           ┃
+// ┃ 4  | TIS-B and ADS-R management                             ┃
+// ┃ 5  | Fine TIS-B, non-ICAO address                           ┃
+// ┃ 6  | ADS-R, address type from the IMF bit                   ┃
+// ┃ 7  | Reserved                                               ┃
+// ┗━━━━┷━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛
 
 const (
 	extendedSquitterName = "extended squitter"
 )
 
-// ExtendedSquitter is long message with downlink 16 or 17.
+// ControlField is the CF of the DF18 messages.
+type ControlField byte
+
+const (
+	// ControlFieldADSB is ADS-B from a non-transponder device, with the ICAO address.
+	ControlFieldADSB ControlField = 0
+	// ControlFieldADSBNonICAO is ADS-B from a non-transponder device, with an anonymous or ground vehicle address.
+	ControlFieldADSBNonICAO ControlField = 1
+	// ControlFieldFineTISB is the fine TIS-B, with the address type in the IMF bit.
+	ControlFieldFineTISB ControlField = 2
+	// ControlFieldCoarseTISB is the coarse TIS-B airborne position and velocity.
+	ControlFieldCoarseTISB ControlField = 3
+	// ControlFieldManagement is the TIS-B and ADS-R management messages.
+	ControlFieldManagement ControlField = 4
+	// ControlFieldFineTISBNonICAO is the fine TIS-B, with a non-ICAO address.
+	ControlFieldFineTISBNonICAO ControlField = 5
+	// ControlFieldADSR is the ADS-R rebroadcast, with the address type in the IMF bit.
+	ControlFieldADSR ControlField = 6
+
+	controlFieldReserved ControlField = 7
+)
+
+// ExtendedSquitter is long message with downlink 17, 18 or 19.
 type ExtendedSquitter LongMessage
 
 // TransponderCapability is the CA.
@@ -69,9 +107,103 @@ func (e ExtendedSquitter) TransponderCapability() TransponderCapability {
 	return TransponderCapability(e.ModeS[0] & 0x07) //nolint: gomnd
 }
 
+// ControlField is the CF of the DF18 messages.
+func (e ExtendedSquitter) ControlField() ControlField {
+	return ControlField(e.ModeS[0] & 0x07) //nolint: gomnd
+}
+
+// Source is the origin of the message: ADS-B, or rebroadcasted by a ground station.
+// The military extended squitters are not ADS-B.
+func (e ExtendedSquitter) Source() Source {
+	if e.DownlinkFormat() == DownlinkFormatExtendedSquitter {
+		return SourceADSB
+	}
+
+	if e.DownlinkFormat() != DownlinkFormatExtendedSquitterNonTransponder {
+		return SourceModeS
+	}
+
+	switch e.ControlField() { //nolint: exhaustive
+	case ControlFieldADSB, ControlFieldADSBNonICAO:
+		return SourceADSB
+	case ControlFieldADSR:
+		return SourceADSR
+	default:
+		return SourceTISB
+	}
+}
+
+// AddressType is the type of the aircraft address; the DF18 messages may carry non-ICAO addresses.
+func (e ExtendedSquitter) AddressType() AddressType {
+	if e.DownlinkFormat() != DownlinkFormatExtendedSquitterNonTransponder {
+		return AddressTypeICAO
+	}
+
+	switch e.ControlField() { //nolint: exhaustive
+	case ControlFieldADSBNonICAO, ControlFieldFineTISBNonICAO:
+		return AddressTypeNonICAO
+	case ControlFieldFineTISB, ControlFieldADSR:
+		return e.imfAddressType()
+	case ControlFieldCoarseTISB:
+		return AddressType(e.ModeS[4] >> 7) //nolint: gomnd
+	default:
+		return AddressTypeICAO
+	}
+}
+
+// imfAddressType reads the IMF bit, which replaces a bit of the ADS-B message in the positions and velocities.
+// The other messages carry the ICAO address.
+func (e ExtendedSquitter) imfAddressType() AddressType {
+	var position uint64
+
+	switch e.TypeCode() { //nolint: exhaustive
+	case TypeCodeAirbornePositionBaroAltitude, TypeCodeAirbornePositionGNSSHeight:
+		position = 8 // SAF
+	case TypeCodeSurfacePosition:
+		position = 21 // T
+	case TypeCodeAirborneVelocities:
+		position = 9 // IC
+	default:
+		return AddressTypeICAO
+	}
+
+	return AddressType(binary.ReadBits(e.ModeS[4:11], position-1, 1))
+}
+
+// hasAircraftAddress checks if the message carries an aircraft address: the DF18 management and reserved messages do not.
+func (e ExtendedSquitter) hasAircraftAddress() bool {
+	if e.DownlinkFormat() != DownlinkFormatExtendedSquitterNonTransponder {
+		return true
+	}
+
+	switch e.ControlField() { //nolint: exhaustive
+	case ControlFieldManagement, controlFieldReserved:
+		return false
+	default:
+		return true
+	}
+}
+
+// decodable checks if the message carries a regular ADS-B message.
+func (e ExtendedSquitter) decodable() bool {
+	switch e.DownlinkFormat() { //nolint: exhaustive
+	case DownlinkFormatExtendedSquitter:
+		return true
+	case DownlinkFormatExtendedSquitterNonTransponder:
+		switch e.ControlField() { //nolint: exhaustive
+		case ControlFieldADSB, ControlFieldADSBNonICAO, ControlFieldFineTISB, ControlFieldFineTISBNonICAO, ControlFieldADSR:
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
 // Decode decodes the mode-s frame.
 func (e ExtendedSquitter) Decode() (NamedExtendedSquitter, error) { //nolint: ireturn
-	if e.DownlinkFormat() == DownlinkFormatExtendedSquitter && len(e.ModeS) == 112/8 {
+	if e.decodable() && len(e.ModeS) == 112/8 {
 		switch e.TypeCode() { //nolint: exhaustive
 		case TypeCodeAircraftIdentification:
 			return Identification{ExtendedSquitter: e}, nil
@@ -88,6 +220,12 @@ func (e ExtendedSquitter) Decode() (NamedExtendedSquitter, error) { //nolint: ir
 		case TypeCodeAirborneVelocities:
 			return AirborneVelocity{ExtendedSquitter: e}, nil
 
+		case TypeCodeAircraftStatus:
+			return AircraftStatus{ExtendedSquitter: e}, nil
+
+		case TypeCodeTargetStateAndStatusInformation:
+			return TargetStateAndStatus{ExtendedSquitter: e}, nil
+
 		default:
 			return nil, ErrUnsupportedFormat
 		}
@@ -106,11 +244,15 @@ func (e ExtendedSquitter) SubTypeCode() SubTypeCode {
 	return SubTypeCode(e.ModeS[4] & 0x07) //nolint: gomnd
 }
 
-// AircraftAddress implements the Squitter interface.
+// AircraftAddress implements the Squitter interface; 0 when the message does not carry any aircraft address.
 func (e ExtendedSquitter) AircraftAddress() ICAOAddr {
-	return ICAOAddr((uint32(e.ModeS[1]) << 16) + //nolint: gomnd
-		(uint32(e.ModeS[2]) << 8) + //nolint: gomnd
-		uint32(e.ModeS[3]))
+	if !e.hasAircraftAddress() {
+		return 0
+	}
+
+	return NewAddress((uint32(e.ModeS[1])<<16)+ //nolint: gomnd
+		(uint32(e.ModeS[2])<<8)+ //nolint: gomnd
+		uint32(e.ModeS[3]), e.AddressType())
 }
 
 // Name implements the Squitter interface.
@@ -0,0 +1,101 @@
+package model_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonTransponderSquitter(t *testing.T) {
+	t.Parallel()
+
+	for _, fixtureElt := range []struct {
+		name        string
+		frame       string
+		source      model.Source
+		addr        model.ICAOAddr
+		message     string
+		unsupported bool
+	}{
+		{
+			name:    "ADS-B",
+			frame:   "8D4840D6202CC371C32CE0576098",
+			source:  model.SourceADSB,
+			addr:    model.NewAddress(0x4840D6, model.AddressTypeICAO),
+			message: "identification",
+		},
+		{
+			name:    "non-ICAO ADS-B",
+			frame:   "914840D6202CC371C32CE0721D15",
+			source:  model.SourceADSB,
+			addr:    model.NewAddress(0x4840D6, model.AddressTypeNonICAO),
+			message: "identification",
+		},
+		{
+			name:    "fine TIS-B with IMF",
+			frame:   "9240621D59C382D690C8AC39F755",
+			source:  model.SourceTISB,
+			addr:    model.NewAddress(0x40621D, model.AddressTypeNonICAO),
+			message: "airborne position",
+		},
+		{
+			name:        "coarse TIS-B",
+			frame:       "9340621D80C382D690C8ACB93DF1",
+			source:      model.SourceTISB,
+			addr:        model.NewAddress(0x40621D, model.AddressTypeNonICAO),
+			unsupported: true,
+		},
+		{
+			name:        "management",
+			frame:       "9440621D58C382D690C8ACCB5EBB",
+			source:      model.SourceTISB,
+			unsupported: true,
+		},
+		{
+			name:        "reserved",
+			frame:       "9740621D58C382D690C8AC23CD33",
+			source:      model.SourceTISB,
+			unsupported: true,
+		},
+		{
+			name:    "ADS-R",
+			frame:   "964840D6202CC371C32CE004BF74",
+			source:  model.SourceADSR,
+			addr:    model.NewAddress(0x4840D6, model.AddressTypeICAO),
+			message: "identification",
+		},
+	} {
+		fixture := fixtureElt
+
+		t.Run(fixture.name, func(t *testing.T) {
+			t.Parallel()
+
+			dataByte, err := hex.DecodeString(fixture.frame)
+			require.NoError(t, err)
+
+			require.NoError(t, model.ModeS(dataByte).CheckSum())
+
+			squitter, err := model.ModeS(dataByte).QualifiedMessage()
+			require.NoError(t, err)
+
+			extendedSquitter, ok := squitter.(model.ExtendedSquitter)
+			require.True(t, ok)
+
+			assert.Equal(t, fixture.source, extendedSquitter.Source())
+			assert.Equal(t, fixture.addr, extendedSquitter.AircraftAddress())
+
+			msg, err := extendedSquitter.Decode()
+			if fixture.unsupported {
+				require.ErrorIs(t, err, model.ErrUnsupportedFormat)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, fixture.message, msg.Name())
+		})
+	}
+}
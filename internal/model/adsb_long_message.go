@@ -16,7 +16,7 @@ type LongMessage struct {
 
 // AircraftAddress implements the Squitter interface.
 func (l LongMessage) AircraftAddress() ICAOAddr {
-	return 0
+	return l.IcaoAddrChecksum()
 }
 
 // Message is the extended squitter message.
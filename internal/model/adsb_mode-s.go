@@ -124,18 +124,24 @@ func (m ModeS) QualifiedMessage() (QualifiedMessage, error) { //nolint: ireturn
 	return nil, fmt.Errorf("DF:%d / len:%d / msg:%s / err:%w", downlinkFormat, len(m), m, ErrUnsupportedFormat)
 }
 
-// IcaoAddrChecksum is when the message type has the checksum xored with the ICAO address,
-// this function extracts the ICAO address.
-func (m ModeS) IcaoAddrChecksum() ICAOAddr {
+// AddressParity checks if the message type has the checksum xored with the ICAO address.
+// Any frame of those types gives an address: it cannot be validated without knowing the aircraft.
+func (m ModeS) AddressParity() bool {
 	downlinkFormat := m.DownlinkFormat()
 
-	if downlinkFormat == DownlinkFormatShortAirAirSurveillance || // Short air-air surveillance (0)
+	return downlinkFormat == DownlinkFormatShortAirAirSurveillance || // Short air-air surveillance (0)
 		downlinkFormat == DownlinkFormatAltitudeReply || // Surveillance, altitude reply (4)
 		downlinkFormat == DownlinkFormatIdentityReply || // Surveillance, identity reply (5)
 		downlinkFormat == DownlinkFormatLongAirAirSurveillance || // Long air-air survillance (16)
 		downlinkFormat == DownlinkFormatCommBWithAltitudeReply || // Comm-B, altitude request (20)
 		downlinkFormat == DownlinkFormatCommBWithIdentityReply || // Comm-B, identity request (21)
-		downlinkFormat == DownlinkFormatCommDExtendedLengthMessage { // Comm-D ELM (24)
+		downlinkFormat == DownlinkFormatCommDExtendedLengthMessage // Comm-D ELM (24)
+}
+
+// IcaoAddrChecksum is when the message type has the checksum xored with the ICAO address,
+// this function extracts the ICAO address.
+func (m ModeS) IcaoAddrChecksum() ICAOAddr {
+	if m.AddressParity() {
 		remainder := binary.ChecksumSquitter(m[:len(m)-3])
 
 		return ICAOAddr(uint32(m[len(m)-1]^byte(remainder)) |
@@ -162,6 +168,27 @@ func (m ModeS) CheckSum() error {
 	return nil
 }
 
+// Fix repairs, in place, the bit errors of the frames having a plain parity (11, 17, 18).
+// It returns the number of fixed bits, or the checksum error when the frame cannot be repaired.
+func (m ModeS) Fix(corrector *binary.ErrorCorrector) (int, error) {
+	checksumErr := m.CheckSum()
+	if checksumErr == nil {
+		return 0, nil
+	}
+
+	// All-call replies may carry the interrogator identifier in the 7 last bits of the parity.
+	if m.DownlinkFormat() == DownlinkFormatAllCallReply && binary.Syndrome(m)&0xffff80 == 0 {
+		return 0, nil
+	}
+
+	fixed, err := corrector.Correct(m)
+	if err != nil {
+		return 0, checksumErr
+	}
+
+	return fixed, nil
+}
+
 // String implements the Stringer interface.
 func (m ModeS) String() string {
 	return strings.ToUpper(hex.EncodeToString(m))
@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/landru29/adsb1090/internal/binary"
 	"github.com/landru29/adsb1090/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -132,3 +133,46 @@ func TestIcaoAddrChecksum(t *testing.T) {
 		assert.Equal(t, model.ICAOAddr(0x346204), model.ModeS(dataByte).IcaoAddrChecksum())
 	})
 }
+
+func TestFix(t *testing.T) {
+	t.Parallel()
+
+	t.Run("one bit error in an extended squitter", func(t *testing.T) {
+		t.Parallel()
+
+		// 8D40621D58C382D690C8AC2863A7 with bit #42 flipped.
+		dataByte, err := hex.DecodeString("8D40621D58C3A2D690C8AC2863A7")
+		require.NoError(t, err)
+
+		require.ErrorIs(t, model.ModeS(dataByte).CheckSum(), model.ErrWrongCRC)
+
+		fixed, err := model.ModeS(dataByte).Fix(binary.NewErrorCorrector(1))
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, fixed)
+		assert.Equal(t, "8D40621D58C382D690C8AC2863A7", model.ModeS(dataByte).String())
+	})
+
+	t.Run("correction disabled", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("8D40621D58C3A2D690C8AC2863A7")
+		require.NoError(t, err)
+
+		_, err = model.ModeS(dataByte).Fix(nil)
+		require.ErrorIs(t, err, model.ErrWrongCRC)
+	})
+
+	t.Run("address parity is not fixed", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("A0001839CA380030AA0000C8B28A")
+		require.NoError(t, err)
+
+		fixed, err := model.ModeS(dataByte).Fix(binary.NewErrorCorrector(2))
+		require.NoError(t, err)
+
+		assert.Zero(t, fixed)
+		assert.Equal(t, "A0001839CA380030AA0000C8B28A", model.ModeS(dataByte).String())
+	})
+}
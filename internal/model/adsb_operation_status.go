@@ -1,5 +1,12 @@
 package model
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/landru29/adsb1090/internal/binary"
+)
+
 //       ┏━━━━━┓
 //       ┃ 1-4 ┃
 //       ┣━━━━━╇━━━━┯━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓
@@ -7,17 +14,105 @@ package model
 //       ┠┈┈┈┈┈┼┈┈┈┈┼┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┨
 //       ┃ 5   |  3 |                         48                                 ┃
 //       ┗━━━━━╈━━━━╇━━━━━┯━━━━┯━━━━━┯━━━━━━┯━━━━━━┯━━━━━┯━━━━━┯━━━━━┯━━━━━┯━━━━━┫
-//             ┃ =0 | CC  | OM | Ver | NICs | NACp | BAQ | SIL | BAI | HRD | Res ┃
-//             ┗━━━━╅┈┈┈┈┈┼┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┨
-//                  ┃ 16  | 16 |  3  |  1   |  4   |  2  |  2  |  1  |  1  |  2  ┃
-//             ┏━━━━╇━━━━━┷━━━━┷━━━━━┷━━━━━━┷━━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┫
-//             ┃ =1 | CC  | OM | Ver | NICs | NACp | Res | SIL | BAI | HRD | Res ┃
+//             ┃ =0 | CC  | OM | Ver | NICa | NACp | GVA | SIL | BAI | HRD | SIS ┃
 //             ┗━━━━╅┈┈┈┈┈┼┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┨
 //                  ┃ 16  | 16 |  3  |  1   |  4   |  2  |  2  |  1  |  1  |  2  ┃
-//                  ┗━━━━━┷━━━━┷━━━━━┷━━━━━━┷━━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┛
+//             ┏━━━━╇━━━━━┯━━━━┿━━━━┯━━━━━┯━━━━━━┯━━━━━━┯━━━━━┯━━━━━┯━━━━━┯━━━━━┯━━━━━┫
+//             ┃ =1 | CC  | LW | OM | Ver | NICa | NACp | Res | SIL | TAH | HRD | SIS ┃
+//             ┗━━━━╅┈┈┈┈┈┼┈┈┈┈┼┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┨
+//                  ┃ 12  | 4  | 16 |  3  |  1   |  4   |  2  |  2  |  1  |  1  |  2  ┃
+//                  ┗━━━━━┷━━━━┷━━━━┷━━━━━┷━━━━━━┷━━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┷━━━━━┛
+//
+// CC: capability class, OM: operational mode, LW: length/width code, TAH: track angle/heading,
+// BAI: barometric altitude integrity, HRD: horizontal reference direction,
+// SIS: SIL supplement (1 bit) and reserved (1 bit).
 
 const operationStatusName = "operation status"
 
+const (
+	// OperationStatusAirborne is the subtype of the airborne operation status.
+	OperationStatusAirborne SubTypeCode = 0
+	// OperationStatusSurface is the subtype of the surface operation status.
+	OperationStatusSurface SubTypeCode = 1
+)
+
+// ADSBVersion is the ADS-B version number (DO-260 = 0, DO-260A = 1, DO-260B = 2).
+type ADSBVersion uint8
+
+const (
+	// ADSBVersion0 is the DO-260 version.
+	ADSBVersion0 ADSBVersion = 0
+	// ADSBVersion1 is the DO-260A version.
+	ADSBVersion1 ADSBVersion = 1
+	// ADSBVersion2 is the DO-260B version.
+	ADSBVersion2 ADSBVersion = 2
+)
+
+// CapabilityClass is the CC field (16 bits for airborne status, 12 bits for surface status).
+type CapabilityClass uint16
+
+// OperationalMode is the OM field.
+type OperationalMode uint16
+
+// NACp is the navigation accuracy category for position.
+type NACp uint8
+
+// GVA is the geometric vertical accuracy.
+type GVA uint8
+
+// SIL is the source integrity level.
+type SIL uint8
+
+// SDA is the system design assurance.
+type SDA uint8
+
+// OperationalStatus is the decoded aircraft operation status.
+type OperationalStatus struct {
+	Surface             bool            `json:"surface"`
+	Version             ADSBVersion     `json:"version"`
+	CapabilityClass     CapabilityClass `json:"capabilityClass"`
+	OperationalMode     OperationalMode `json:"operationalMode"`
+	NICSupplementA      bool            `json:"nicSupplementA"`
+	NACp                NACp            `json:"nacp"`
+	GVA                 GVA             `json:"gva"`
+	SIL                 SIL             `json:"sil"`
+	SILSupplement       bool            `json:"silSupplement"`
+	SDA                 SDA             `json:"sda"`
+	MagneticNorth       bool            `json:"magneticNorth"` /* HRD: headings are referenced to the magnetic north. */
+	TCASOperational     bool            `json:"tcasOperational"`
+	TCASRAActive        bool            `json:"tcasRaActive"`
+	IdentActive         bool            `json:"identActive"`
+	SingleAntenna       bool            `json:"singleAntenna"`
+	LengthWidth         uint8           `json:"lengthWidth,omitempty"`         /* Surface only. */
+	GPSAntennaOffset    uint8           `json:"gpsAntennaOffset,omitempty"`    /* Surface only. */
+	HeadingReported     bool            `json:"headingReported,omitempty"`     /* Surface only: heading instead of track angle. */
+	BarometricIntegrity bool            `json:"barometricIntegrity,omitempty"` /* Airborne only: NIC baro. */
+}
+
+// String implements the Stringer interface.
+func (s OperationalStatus) String() string {
+	fields := []string{"airborne"}
+	if s.Surface {
+		fields[0] = "surface"
+	}
+
+	fields = append(fields,
+		fmt.Sprintf("ADS-B v%d", s.Version),
+		fmt.Sprintf("NACp %d", s.NACp),
+		fmt.Sprintf("SIL %d", s.SIL),
+	)
+
+	if s.TCASRAActive {
+		fields = append(fields, "TCAS RA")
+	}
+
+	if s.IdentActive {
+		fields = append(fields, "IDENT")
+	}
+
+	return strings.Join(fields, ", ")
+}
+
 // OperationStatus is the operation status.
 type OperationStatus struct {
 	ExtendedSquitter
@@ -31,10 +126,140 @@ func (o OperationStatus) Name() string {
 // String implements the Stringer interface.
 // It's the name of the current operation.
 func (o OperationStatus) String() string {
-	return "" //TODO: Not implemented
+	return o.Status().String()
 }
 
 // Message is the data byte.
 func (o OperationStatus) Message() []byte {
 	return LongMessage(o.ExtendedSquitter).Message()
 }
+
+// IsSurface checks if this is the surface operation status.
+func (o OperationStatus) IsSurface() bool {
+	return o.SubTypeCode() == OperationStatusSurface
+}
+
+// Version is the ADS-B version.
+func (o OperationStatus) Version() ADSBVersion {
+	return ADSBVersion(o.bits(41, 3)) //nolint: gomnd
+}
+
+// CapabilityClass is the capability class.
+func (o OperationStatus) CapabilityClass() CapabilityClass {
+	if o.IsSurface() {
+		return CapabilityClass(o.bits(9, 12)) //nolint: gomnd
+	}
+
+	return CapabilityClass(o.bits(9, 16)) //nolint: gomnd
+}
+
+// OperationalMode is the operational mode.
+func (o OperationStatus) OperationalMode() OperationalMode {
+	return OperationalMode(o.bits(25, 16)) //nolint: gomnd
+}
+
+// NICSupplementA is the NIC supplement used with the position type code to get the NIC.
+func (o OperationStatus) NICSupplementA() bool {
+	return o.bits(44, 1) == 1 //nolint: gomnd
+}
+
+// NACp is the navigation accuracy category for position.
+func (o OperationStatus) NACp() NACp {
+	return NACp(o.bits(45, 4)) //nolint: gomnd
+}
+
+// GVA is the geometric vertical accuracy (airborne only).
+func (o OperationStatus) GVA() GVA {
+	if o.IsSurface() {
+		return 0
+	}
+
+	return GVA(o.bits(49, 2)) //nolint: gomnd
+}
+
+// SIL is the source integrity level.
+func (o OperationStatus) SIL() SIL {
+	return SIL(o.bits(51, 2)) //nolint: gomnd
+}
+
+// SILSupplement is true when the SIL is given per sample instead of per hour.
+func (o OperationStatus) SILSupplement() bool {
+	return o.bits(55, 1) == 1 //nolint: gomnd
+}
+
+// MagneticNorth is the horizontal reference direction (HRD).
+func (o OperationStatus) MagneticNorth() bool {
+	return o.bits(54, 1) == 1 //nolint: gomnd
+}
+
+// SDA is the system design assurance.
+func (o OperationStatus) SDA() SDA {
+	return SDA(o.bits(31, 2)) //nolint: gomnd
+}
+
+// TCASOperational checks if the TCAS is operational (airborne only).
+// In version 1, the bit means that TCAS is NOT operational.
+func (o OperationStatus) TCASOperational() bool {
+	if o.IsSurface() {
+		return false
+	}
+
+	bit := o.bits(11, 1) == 1 //nolint: gomnd
+
+	if o.Version() == ADSBVersion1 {
+		return !bit
+	}
+
+	return bit
+}
+
+// TCASRAActive checks if a TCAS resolution advisory is active.
+func (o OperationStatus) TCASRAActive() bool {
+	return o.bits(27, 1) == 1 //nolint: gomnd
+}
+
+// IdentActive checks if the IDENT switch is active.
+func (o OperationStatus) IdentActive() bool {
+	return o.bits(28, 1) == 1 //nolint: gomnd
+}
+
+// SingleAntenna checks if the transponder has a single antenna.
+func (o OperationStatus) SingleAntenna() bool {
+	return o.bits(30, 1) == 1 //nolint: gomnd
+}
+
+// Status is the decoded operation status.
+func (o OperationStatus) Status() OperationalStatus {
+	output := OperationalStatus{
+		Surface:         o.IsSurface(),
+		Version:         o.Version(),
+		CapabilityClass: o.CapabilityClass(),
+		OperationalMode: o.OperationalMode(),
+		NICSupplementA:  o.NICSupplementA(),
+		NACp:            o.NACp(),
+		GVA:             o.GVA(),
+		SIL:             o.SIL(),
+		SILSupplement:   o.SILSupplement(),
+		SDA:             o.SDA(),
+		MagneticNorth:   o.MagneticNorth(),
+		TCASOperational: o.TCASOperational(),
+		TCASRAActive:    o.TCASRAActive(),
+		IdentActive:     o.IdentActive(),
+		SingleAntenna:   o.SingleAntenna(),
+	}
+
+	if output.Surface {
+		output.LengthWidth = uint8(o.bits(21, 4))      //nolint: gomnd
+		output.GPSAntennaOffset = uint8(o.bits(33, 8)) //nolint: gomnd
+		output.HeadingReported = o.bits(53, 1) == 1    //nolint: gomnd
+	} else {
+		output.BarometricIntegrity = o.bits(53, 1) == 1 //nolint: gomnd
+	}
+
+	return output
+}
+
+// bits reads the message bits; the position starts at 1, like in the specifications.
+func (o OperationStatus) bits(position uint64, count uint8) uint64 {
+	return binary.ReadBits(o.Message(), position-1, count)
+}
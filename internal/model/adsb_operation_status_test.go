@@ -35,4 +35,78 @@ func TestOperationStatus(t *testing.T) {
 		_, ok = msg.(model.OperationStatus)
 		assert.True(t, ok)
 	})
+
+	t.Run("airborne", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("8D4CA92BF8230006004BB8FB39CA")
+		require.NoError(t, err)
+
+		msg, err := model.ExtendedSquitter{ModeS: dataByte}.Decode()
+		require.NoError(t, err)
+
+		operationStatus, ok := msg.(model.OperationStatus)
+		require.True(t, ok)
+
+		assert.Equal(t, model.OperationalStatus{
+			Version:             model.ADSBVersion2,
+			CapabilityClass:     0x2300,
+			OperationalMode:     0x0600,
+			NACp:                11,
+			GVA:                 2,
+			SIL:                 3,
+			SDA:                 2,
+			TCASOperational:     true,
+			SingleAntenna:       true,
+			BarometricIntegrity: true,
+		}, operationStatus.Status())
+
+		assert.Equal(t, "airborne, ADS-B v2, NACp 11, SIL 3", operationStatus.String())
+	})
+
+	t.Run("surface", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("8C4CA92BF9203532045A3E000000")
+		require.NoError(t, err)
+
+		msg, err := model.ExtendedSquitter{ModeS: dataByte}.Decode()
+		require.NoError(t, err)
+
+		operationStatus, ok := msg.(model.OperationStatus)
+		require.True(t, ok)
+
+		assert.Equal(t, model.OperationalStatus{
+			Surface:          true,
+			Version:          model.ADSBVersion2,
+			CapabilityClass:  0x203,
+			OperationalMode:  0x3204,
+			NICSupplementA:   true,
+			NACp:             10,
+			SIL:              3,
+			SILSupplement:    true,
+			SDA:              2,
+			MagneticNorth:    true,
+			TCASRAActive:     true,
+			IdentActive:      true,
+			LengthWidth:      5,
+			GPSAntennaOffset: 4,
+			HeadingReported:  true,
+		}, operationStatus.Status())
+
+		assert.Equal(t, "surface, ADS-B v2, NACp 10, SIL 3, TCAS RA, IDENT", operationStatus.String())
+	})
+
+	t.Run("TCAS in version 1", func(t *testing.T) {
+		t.Parallel()
+
+		// same as the airborne status, with version 1: the CC bit means 'TCAS not operational'.
+		dataByte, err := hex.DecodeString("8D4CA92BF8230006002BB8000000")
+		require.NoError(t, err)
+
+		operationStatus := model.OperationStatus{ExtendedSquitter: model.ExtendedSquitter{ModeS: dataByte}}
+
+		assert.Equal(t, model.ADSBVersion1, operationStatus.Version())
+		assert.False(t, operationStatus.TCASOperational())
+	})
 }
@@ -15,7 +15,12 @@ type ShortMessage struct {
 }
 
 // AircraftAddress implements the Squitter interface.
+// The all-call replies announce the address; the other replies have it in the parity.
 func (s ShortMessage) AircraftAddress() ICAOAddr {
+	if s.DownlinkFormat() == DownlinkFormatAllCallReply {
+		return AllCallReply{ShortMessage: s}.AnnouncedAddress()
+	}
+
 	return s.IcaoAddrChecksum()
 }
 
@@ -30,18 +30,22 @@ func (p SurfacePosition) Name() string {
 	return surfacePositionName
 }
 
-// GroundTrack is the ground track.
+// GroundTrackValid checks the status of the ground track.
+func (p SurfacePosition) GroundTrackValid() bool {
+	return (p.Message()[1]>>3)&0x1 == 1 //nolint: gomnd
+}
+
+// GroundTrack is the ground track in degrees; -1 when the track is not valid.
 func (p SurfacePosition) GroundTrack() float64 {
+	if !p.GroundTrackValid() {
+		return -1
+	}
+
 	message := p.Message()
 
 	groundTrackByte := ((message[1] & 0x7) << 4) | ((message[2]) >> 4) //nolint: gomnd
-	groundTrack := float64(-1)
 
-	if (message[1]>>3)&0x1 == 1 { //nolint: gomnd
-		groundTrack = float64(360) * float64(groundTrackByte) / float64(128) //nolint: gomnd
-	}
-
-	return groundTrack
+	return float64(360) * float64(groundTrackByte) / float64(128) //nolint: gomnd
 }
 
 // TimeUTC define whether the time is UTC or not.
@@ -68,36 +72,52 @@ func (p SurfacePosition) EncodedLongitude() uint32 {
 	return ((uint32(message[4]) & 0x1) << 16) | (uint32(message[5]) << 8) | uint32(message[6]) //nolint: gomnd
 }
 
-// Speed is the ground speed.
-func (p SurfacePosition) Speed() float64 {
-	var speed float64
-
+// Movement is the 7 bits encoded ground speed.
+func (p SurfacePosition) Movement() uint8 {
 	message := p.Message()
 
-	encodedMovement := ((message[0] & 0x7) << 4) | ((message[1]) >> 4) //nolint: gomnd
+	return ((message[0] & 0x7) << 4) | ((message[1]) >> 4) //nolint: gomnd
+}
+
+// Speed is the ground speed in knots; -1 when not available.
+// The movement is quantized, with a step increasing with the speed:
+//
+//	code      speed (kt)   step (kt)
+//	0         not available
+//	1         stopped
+//	2-8       0.125-0.875  0.125
+//	9-12      1-1.75       0.25
+//	13-38     2-14.5       0.5
+//	39-93     15-69        1
+//	94-108    70-98        2
+//	109-123   100-170      5
+//	124       175 and more
+//	125-127   reserved
+func (p SurfacePosition) Speed() float64 { //nolint: cyclop
+	movement := p.Movement()
 
 	switch {
-	case encodedMovement == 0:
-		speed = -1
-	case encodedMovement == 1:
-		speed = 0
-	case encodedMovement < 8: //nolint: gomnd
-		speed = float64(0.125) * float64(encodedMovement-1) //nolint: gomnd
-	case encodedMovement < 12: //nolint: gomnd
-		speed = float64(1) + float64(0.25)*float64(encodedMovement-9) //nolint: gomnd
-	case encodedMovement < 38: //nolint: gomnd
-		speed = float64(2) + float64(0.5)*float64(encodedMovement-13) //nolint: gomnd
-	case encodedMovement < 93: //nolint: gomnd
-		speed = float64(15) + float64(encodedMovement-39) //nolint: gomnd
-	case encodedMovement < 108: //nolint: gomnd
-		speed = float64(70) + float64(2)*float64(encodedMovement-94) //nolint: gomnd
-	case encodedMovement < 123: //nolint: gomnd
-		speed = float64(100) + float64(5)*float64(encodedMovement-109) //nolint: gomnd
-	default:
-		speed = 175.1
+	case movement == 0:
+		return -1
+	case movement == 1:
+		return 0
+	case movement <= 8: //nolint: gomnd
+		return 0.125 + 0.125*float64(movement-2) //nolint: gomnd
+	case movement <= 12: //nolint: gomnd
+		return 1 + 0.25*float64(movement-9) //nolint: gomnd
+	case movement <= 38: //nolint: gomnd
+		return 2 + 0.5*float64(movement-13) //nolint: gomnd
+	case movement <= 93: //nolint: gomnd
+		return 15 + float64(movement-39) //nolint: gomnd
+	case movement <= 108: //nolint: gomnd
+		return 70 + 2*float64(movement-94) //nolint: gomnd
+	case movement <= 123: //nolint: gomnd
+		return 100 + 5*float64(movement-109) //nolint: gomnd
+	case movement == 124: //nolint: gomnd
+		return 175 //nolint: gomnd
 	}
 
-	return speed
+	return -1
 }
 
 // Message is the data byte.
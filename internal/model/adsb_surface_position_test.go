@@ -75,3 +75,90 @@ func TestSurfacePosition(t *testing.T) {
 		})
 	}
 }
+
+func TestSurfaceMovement(t *testing.T) {
+	t.Parallel()
+
+	for _, fixture := range []struct {
+		movement uint8
+		speed    float64
+	}{
+		{movement: 0, speed: -1},
+		{movement: 1, speed: 0},
+		{movement: 2, speed: 0.125},
+		{movement: 8, speed: 0.875},
+		{movement: 9, speed: 1},
+		{movement: 12, speed: 1.75},
+		{movement: 13, speed: 2},
+		{movement: 38, speed: 14.5},
+		{movement: 39, speed: 15},
+		{movement: 93, speed: 69},
+		{movement: 94, speed: 70},
+		{movement: 108, speed: 98},
+		{movement: 109, speed: 100},
+		{movement: 123, speed: 170},
+		{movement: 124, speed: 175},
+		{movement: 125, speed: -1},
+		{movement: 127, speed: -1},
+	} {
+		data := make([]byte, 14)
+		data[0] = 0x8C
+		data[4] = 0x38 | fixture.movement>>4
+		data[5] = fixture.movement << 4
+
+		position := model.SurfacePosition{ExtendedSquitter: model.ExtendedSquitter{ModeS: model.ModeS(data)}}
+
+		assert.Equal(t, fixture.movement, position.Movement())
+		assert.Equal(t, fixture.speed, position.Speed(), "movement %d", fixture.movement) //nolint: testifylint
+		assert.False(t, position.GroundTrackValid())
+		assert.Equal(t, float64(-1), position.GroundTrack()) //nolint: testifylint
+	}
+}
+
+func TestDecodeSurfacePosition(t *testing.T) {
+	t.Parallel()
+
+	surfacePosition := func(t *testing.T, str string) model.SurfacePosition {
+		t.Helper()
+
+		dataByte, err := hex.DecodeString(str)
+		require.NoError(t, err)
+
+		return model.SurfacePosition{ExtendedSquitter: model.ExtendedSquitter{ModeS: model.ModeS(dataByte)}}
+	}
+
+	even := surfacePosition(t, "8C4841753AAB238733C8CD4020B1")
+	odd := surfacePosition(t, "8C4841753A8A35323FAEBDAC702D")
+
+	for _, fixtureElt := range []struct {
+		name      string
+		reference model.Position
+		latitude  float64
+	}{
+		{
+			name:      "same quadrant",
+			reference: model.Position{Latitude: 51.99, Longitude: 4.37},
+			latitude:  52.32061,
+		},
+		{
+			name:      "southern hemisphere",
+			reference: model.Position{Latitude: -37.0, Longitude: -176.0},
+			latitude:  52.32061 - 90,
+		},
+	} {
+		fixture := fixtureElt
+
+		t.Run(fixture.name, func(t *testing.T) {
+			t.Parallel()
+
+			position, err := model.DecodeSurfacePosition(odd, even, fixture.reference)
+			require.NoError(t, err)
+
+			assert.InDelta(t, fixture.latitude, position.Latitude, 0.00001)
+			assert.InDelta(t, fixture.reference.Longitude, position.Longitude, 45)
+		})
+	}
+
+	_, err := model.DecodeSurfacePosition(odd, odd, model.Position{})
+	require.Error(t, err)
+}
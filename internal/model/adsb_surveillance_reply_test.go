@@ -30,6 +30,17 @@ func TestSurveillanceReplyWithAltitude(t *testing.T) {
 
 		assert.EqualValues(t, 36000.0, surveillanceReplyWithAltitude.Altitude())
 	})
+
+	t.Run("gillham", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("20000C83000000")
+		require.NoError(t, err)
+
+		surveillanceReplyWithAltitude := model.SurveillanceReplyWithAltitude{ShortMessage: model.ShortMessage{ModeS: dataByte}}
+
+		assert.EqualValues(t, 38000.0, surveillanceReplyWithAltitude.Altitude())
+	})
 }
 
 func TestSurveillanceReplyWithIdentification(t *testing.T) {
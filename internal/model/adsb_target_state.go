@@ -0,0 +1,169 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/landru29/adsb1090/internal/binary"
+)
+
+//       ┏━━━━━┓
+//       ┃ 29  ┃
+//       ┣━━━━━╇━━━━┯━━━━━┯━━━━━┯━━━━━━━┯━━━━━━┯━━━━━━┯━━━━━━━┯━━━━━━┯━━━━━━┯━━━━━┯━━━━━┯━━━━━━━┯━━━━━┓
+//       ┃ TC  | ST | SIS | SAT | S-Alt | Baro | HDGs | S-HDG | NACp | NICb | SIL | MFs | Modes | Res ┃
+//       ┠┈┈┈┈┈┼┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┼┈┈┈┈┈┈┈┼┈┈┈┈┈┨
+//       ┃  5  | 2  |  1  |  1  |  11   |  9   |  1   |   9   |  4   |  1   |  2  |  1  |   7   |  2  ┃
+//       ┗━━━━━┷━━━━┷━━━━━┷━━━━━┷━━━━━━━┷━━━━━━┷━━━━━━┷━━━━━━━┷━━━━━━┷━━━━━━┷━━━━━┷━━━━━┷━━━━━━━┷━━━━━┛
+//       1     6    8     9     10      21     30     31      40     44     45    47    48      55
+//
+// SIS: SIL supplement, SAT: selected altitude type (0: MCP/FCU, 1: FMS), HDGs: selected heading status,
+// MFs: status of the MCP/FCU mode bits.
+//
+// Modes: autopilot, VNAV, altitude hold, reserved, approach, TCAS operational, LNAV.
+
+const targetStateAndStatusName = "target state and status"
+
+const (
+	// TargetStateAndStatusVersion2 is the subtype of the target state and status, for the ADS-B version 2.
+	TargetStateAndStatusVersion2 SubTypeCode = 1
+
+	selectedAltitudeFactor = 32
+	baroSettingOrigin      = 800.0
+	baroSettingFactor      = 0.8
+)
+
+// SelectedAltitudeSource is the source of the selected altitude.
+type SelectedAltitudeSource string
+
+const (
+	// SelectedAltitudeSourceMCP is the altitude selected on the mode control panel or flight control unit.
+	SelectedAltitudeSourceMCP SelectedAltitudeSource = "MCP/FCU"
+	// SelectedAltitudeSourceFMS is the altitude selected in the flight management system.
+	SelectedAltitudeSourceFMS SelectedAltitudeSource = "FMS"
+)
+
+// TargetState is the decoded target state and status.
+type TargetState struct {
+	SelectedAltitude       *int64                 `json:"selectedAltitude,omitempty"` /* feet */
+	SelectedAltitudeSource SelectedAltitudeSource `json:"selectedAltitudeSource,omitempty"`
+	BaroSetting            *float64               `json:"baroSetting,omitempty"`     /* QNH in millibars */
+	SelectedHeading        *float64               `json:"selectedHeading,omitempty"` /* degrees */
+	NACp                   NACp                   `json:"nacp"`
+	SIL                    SIL                    `json:"sil"`
+	ModesAvailable         bool                   `json:"modesAvailable"`
+	Autopilot              bool                   `json:"autopilot"`
+	VNAV                   bool                   `json:"vnav"`
+	AltitudeHold           bool                   `json:"altitudeHold"`
+	Approach               bool                   `json:"approach"`
+	TCASOperational        bool                   `json:"tcasOperational"`
+	LNAV                   bool                   `json:"lnav"`
+}
+
+// String implements the Stringer interface.
+func (s TargetState) String() string {
+	fields := []string{}
+
+	if s.SelectedAltitude != nil {
+		fields = append(fields, fmt.Sprintf("alt %d ft (%s)", *s.SelectedAltitude, s.SelectedAltitudeSource))
+	}
+
+	if s.SelectedHeading != nil {
+		fields = append(fields, fmt.Sprintf("hdg %.1f", *s.SelectedHeading))
+	}
+
+	if s.BaroSetting != nil {
+		fields = append(fields, fmt.Sprintf("QNH %.1f", *s.BaroSetting))
+	}
+
+	if s.ModesAvailable {
+		for _, mode := range []struct {
+			name   string
+			active bool
+		}{
+			{name: "AP", active: s.Autopilot},
+			{name: "VNAV", active: s.VNAV},
+			{name: "ALT", active: s.AltitudeHold},
+			{name: "APP", active: s.Approach},
+			{name: "LNAV", active: s.LNAV},
+		} {
+			if mode.active {
+				fields = append(fields, mode.name)
+			}
+		}
+	}
+
+	if s.TCASOperational {
+		fields = append(fields, "TCAS")
+	}
+
+	return strings.Join(fields, ", ")
+}
+
+// TargetStateAndStatus is the target state and status information.
+type TargetStateAndStatus struct {
+	ExtendedSquitter
+}
+
+// Name implements the Message interface.
+func (t TargetStateAndStatus) Name() string {
+	return targetStateAndStatusName
+}
+
+// Message is the data byte.
+func (t TargetStateAndStatus) Message() []byte {
+	return LongMessage(t.ExtendedSquitter).Message()
+}
+
+// State is the decoded target state; only the version 2 format is supported.
+func (t TargetStateAndStatus) State() (TargetState, error) {
+	if t.subType() != TargetStateAndStatusVersion2 {
+		return TargetState{}, ErrUnsupportedFormat
+	}
+
+	output := TargetState{
+		NACp:            NACp(t.bits(40, 4)), //nolint: gomnd
+		SIL:             SIL(t.bits(45, 2)),  //nolint: gomnd
+		ModesAvailable:  t.bits(47, 1) == 1,  //nolint: gomnd
+		TCASOperational: t.bits(53, 1) == 1,  //nolint: gomnd
+	}
+
+	if altitude := int64(t.bits(10, 11)); altitude > 0 { //nolint: gomnd
+		altitude = (altitude - 1) * selectedAltitudeFactor
+		output.SelectedAltitude = &altitude
+
+		output.SelectedAltitudeSource = SelectedAltitudeSourceMCP
+		if t.bits(9, 1) == 1 { //nolint: gomnd
+			output.SelectedAltitudeSource = SelectedAltitudeSourceFMS
+		}
+	}
+
+	if baro := t.bits(21, 9); baro > 0 { //nolint: gomnd
+		setting := baroSettingOrigin + float64(baro-1)*baroSettingFactor
+		output.BaroSetting = &setting
+	}
+
+	if t.bits(30, 1) == 1 { //nolint: gomnd
+		heading := float64(t.bits(31, 9)) * 180.0 / 256.0 //nolint: gomnd
+		output.SelectedHeading = &heading
+	}
+
+	if output.ModesAvailable {
+		output.Autopilot = t.bits(48, 1) == 1    //nolint: gomnd
+		output.VNAV = t.bits(49, 1) == 1         //nolint: gomnd
+		output.AltitudeHold = t.bits(50, 1) == 1 //nolint: gomnd
+		output.Approach = t.bits(52, 1) == 1     //nolint: gomnd
+		output.LNAV = t.bits(54, 1) == 1         //nolint: gomnd
+	}
+
+	return output, nil
+}
+
+// subType is the 2 bits subtype.
+func (t TargetStateAndStatus) subType() SubTypeCode {
+	return SubTypeCode(t.bits(6, 2)) //nolint: gomnd
+}
+
+// bits reads the message bits; the position starts at 1, like in the specifications.
+func (t TargetStateAndStatus) bits(position uint64, count uint8) uint64 {
+	return binary.ReadBits(t.Message(), position-1, count)
+}
@@ -0,0 +1,73 @@
+package model_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetStateAndStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("version 2", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("8DA05629EA21485CBF3F8CADAEEB")
+		require.NoError(t, err)
+
+		require.NoError(t, model.ModeS(dataByte).CheckSum())
+
+		squitter, err := model.ModeS(dataByte).QualifiedMessage()
+		require.NoError(t, err)
+
+		extendedSquitter, ok := squitter.(model.ExtendedSquitter)
+		require.True(t, ok)
+
+		msg, err := extendedSquitter.Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, "target state and status", msg.Name())
+
+		targetState, ok := msg.(model.TargetStateAndStatus)
+		require.True(t, ok)
+
+		state, err := targetState.State()
+		require.NoError(t, err)
+
+		require.NotNil(t, state.SelectedAltitude)
+		assert.Equal(t, int64(16992), *state.SelectedAltitude)
+		assert.Equal(t, model.SelectedAltitudeSourceMCP, state.SelectedAltitudeSource)
+
+		require.NotNil(t, state.BaroSetting)
+		assert.InDelta(t, 1012.8, *state.BaroSetting, 0.01)
+
+		require.NotNil(t, state.SelectedHeading)
+		assert.InDelta(t, 66.8, *state.SelectedHeading, 0.1)
+
+		assert.Equal(t, model.NACp(9), state.NACp)
+		assert.Equal(t, model.SIL(3), state.SIL)
+
+		assert.True(t, state.ModesAvailable)
+		assert.True(t, state.Autopilot)
+		assert.True(t, state.VNAV)
+		assert.False(t, state.AltitudeHold)
+		assert.False(t, state.Approach)
+		assert.True(t, state.TCASOperational)
+		assert.True(t, state.LNAV)
+
+		assert.Equal(t, "alt 16992 ft (MCP/FCU), hdg 66.8, QNH 1012.8, AP, VNAV, LNAV, TCAS", state.String())
+	})
+
+	t.Run("version 1", func(t *testing.T) {
+		t.Parallel()
+
+		dataByte, err := hex.DecodeString("8DA05629E821485CBF3F8C000000")
+		require.NoError(t, err)
+
+		_, err = model.TargetStateAndStatus{ExtendedSquitter: model.ExtendedSquitter{ModeS: dataByte}}.State()
+		require.ErrorIs(t, err, model.ErrUnsupportedFormat)
+	})
+}
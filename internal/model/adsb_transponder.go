@@ -1,6 +1,7 @@
 package model
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -30,6 +31,9 @@ const (
 type TransponderCapability byte
 
 const (
+	// TransponderCapabilityLevel1 is the level 1 transponder, surveillance only.
+	TransponderCapabilityLevel1 TransponderCapability = 0
+
 	// TransponderCapabilityOnGround is the level 2+ transponder, with ability to set CA to 7, on-ground.
 	TransponderCapabilityOnGround TransponderCapability = 4
 
@@ -44,6 +48,42 @@ const (
 	TransponderCapabilityOther TransponderCapability = 7
 )
 
+// String implements the Stringer interface.
+func (t TransponderCapability) String() string {
+	switch t {
+	case TransponderCapabilityLevel1:
+		return "level 1"
+	case TransponderCapabilityOnGround:
+		return "level 2+, on ground"
+	case TransponderCapabilityAirborne:
+		return "level 2+, airborne"
+	case TransponderCapabilityBoth:
+		return "level 2+, on ground or airborne"
+	case TransponderCapabilityOther:
+		return "level 2+, other"
+	default:
+		return "reserved (" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TransponderCapability) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *TransponderCapability) UnmarshalText(data []byte) error {
+	for value := TransponderCapabilityLevel1; value <= TransponderCapabilityOther; value++ {
+		if value.String() == string(data) {
+			*t = value
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: transponder capability %s", ErrUnsupportedFormat, data)
+}
+
 // Squawk is the transponder code.
 type Squawk uint16
 
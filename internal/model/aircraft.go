@@ -12,32 +12,55 @@ import (
 
 // Aircraft is an aircraft description.
 type Aircraft struct {
-	Identification     string         `json:"ident"`
-	CurrentOperation   string         `json:"currentOperation"`
-	IcaoAddress        ICAOAddr       `json:"icaoAddress"`
-	Altitude           float64        `json:"altitude,omitempty"`
-	Position           *Position      `json:"position,omitempty"`
-	Flight             string         `json:"flight"` /* Flight number */
-	FlightStatus       *FlightStatus  `json:"flightStatus,omitempty"`
-	Addr               ICAOAddr       `json:"icao"`                  /* ICAO address */
-	GroundSpeed        *float64       `json:"groundSpeed,omitempty"` /* Velocity computed from EW and NS components. */
-	AirSpeed           *float64       `json:"airSpeed,omitempty"`    /* Velocity computed from EW and NS components. */
-	Track              *float64       `json:"track,omitempty"`       /* Angle of flight. */
-	TrueAirSpeed       bool           `json:"trueAirSpeed"`
-	BaroVerticalRate   bool           `json:"barometricVerticalRate"`
-	DeltaBarometric    int16          `json:"deltaBaro"`
-	Identity           Squawk         `json:"identity"`         /* 13 bits identity (from transponder). */
-	LastUpdate         time.Time      `json:"lastUpdate"`       /* Time at which the last packet was received. */
-	LastFlightStatus   int            `json:"lastFlightStatus"` /* Flight status for DF4,5,20,21 */
-	LastDownlinkFormat DownlinkFormat `json:"downlinkFormat"`   /* Downlink format # */
-	VerticalRate       int64          `json:"verticalRate"`
-	Category           string         `json:"category"`
-	Registration       string         `json:"registration"`
-	ManufacturerName   string         `json:"manufacturerName"`
-	Model              string         `json:"model"`
-	Operator           string         `json:"operator"`
-	Owner              string         `json:"owner"`
-	Built              *time.Time     `json:"built,omitempty"`
+	Identification     string                 `json:"ident"`
+	CurrentOperation   string                 `json:"currentOperation"`
+	IcaoAddress        ICAOAddr               `json:"icaoAddress"`
+	Altitude           float64                `json:"altitude,omitempty"`
+	GeometricAltitude  *float64               `json:"geometricAltitude,omitempty"` /* GNSS altitude in feet. */
+	Position           *Position              `json:"position,omitempty"`
+	Flight             string                 `json:"flight"` /* Flight number */
+	FlightStatus       *FlightStatus          `json:"flightStatus,omitempty"`
+	Addr               ICAOAddr               `json:"icao"`                  /* ICAO address, '~' prefixed when non-ICAO. */
+	AddressType        AddressType            `json:"addressType"`           /* ICAO or non-ICAO address. */
+	Source             Source                 `json:"source"`                /* Last extended squitter source; Mode S with replies only. */
+	GroundSpeed        *float64               `json:"groundSpeed,omitempty"` /* Velocity computed from EW and NS components. */
+	AirSpeed           *float64               `json:"airSpeed,omitempty"`    /* Last air speed, either IAS or TAS. */
+	Track              *float64               `json:"track,omitempty"`       /* True track over the ground. */
+	OnGround           bool                   `json:"onGround"`              /* Reported on the surface. */
+	TrueAirSpeed       bool                   `json:"trueAirSpeed"`
+	BaroVerticalRate   bool                   `json:"barometricVerticalRate"`
+	DeltaBarometric    int16                  `json:"deltaBaro"`
+	Identity           Squawk                 `json:"identity"`             /* 13 bits identity (from transponder). */
+	LastUpdate         time.Time              `json:"lastUpdate"`           /* Time at which the last packet was received. */
+	Seen               FieldsSeen             `json:"seen"`                 /* Reception information of each field. */
+	Messages           uint64                 `json:"messages"`             /* Number of messages received. */
+	RSSI               float64                `json:"rssi,omitempty"`       /* Signal level of the last message, in dBFS. */
+	LastFlightStatus   int                    `json:"lastFlightStatus"`     /* Flight status for DF4,5,20,21 */
+	LastDownlinkFormat DownlinkFormat         `json:"downlinkFormat"`       /* Downlink format # */
+	Capability         *TransponderCapability `json:"capability,omitempty"` /* Transponder capability, from the all-call replies and the ADS-B. */
+	VerticalRate       int64                  `json:"verticalRate"`
+	Category           string                 `json:"category"`
+	Registration       string                 `json:"registration"`
+	ManufacturerName   string                 `json:"manufacturerName"`
+	Model              string                 `json:"model"`
+	Operator           string                 `json:"operator"`
+	Owner              string                 `json:"owner"`
+	Built              *time.Time             `json:"built,omitempty"`
+	ReferenceFound     bool                   `json:"referenceFound"` /* Metadata resolved from the world database. */
+	ADSBVersion        ADSBVersion            `json:"adsbVersion"`
+	OperationalStatus  *OperationalStatus     `json:"operationalStatus,omitempty"`
+	TargetState        *TargetState           `json:"targetState,omitempty"`
+	EmergencyState     Emergency              `json:"emergency"`
+	ResolutionAdvisory *ResolutionAdvisory    `json:"resolutionAdvisory,omitempty"`
+	ACAS               *ACAS                  `json:"acas,omitempty"`
+	SelectedAltitude   *int64                 `json:"selectedAltitude,omitempty"` /* feet */
+	BaroSetting        *float64               `json:"baroSetting,omitempty"`      /* QNH in millibars */
+	MagneticHeading    *float64               `json:"magneticHeading,omitempty"`  /* Heading relative to the magnetic north. */
+	IAS                *float64               `json:"ias,omitempty"`              /* Indicated air speed in knots. */
+	TAS                *float64               `json:"tas,omitempty"`              /* True air speed in knots. */
+	Mach               *float64               `json:"mach,omitempty"`
+	RollAngle          *float64               `json:"rollAngle,omitempty"` /* degrees, positive to the right */
+	TrackRate          *float64               `json:"trackRate,omitempty"` /* degrees per second */
 	LastType           TypeCode
 	LastSubType        SubTypeCode
 }
@@ -49,11 +72,19 @@ func (a Aircraft) String() string {
 		fmt.Sprintf("Reg:       %s", a.Registration),
 		fmt.Sprintf("Model:     %s", a.Model),
 		fmt.Sprintf("Operator:  %s", a.Operator),
-		fmt.Sprintf("Addr:      %06X", a.Addr),
+		fmt.Sprintf("Addr:      %s", a.Addr),
+		fmt.Sprintf("Source:    %s", a.Source),
 		fmt.Sprintf("Category:  %s", a.Category),
 		fmt.Sprintf("flight:    %s", a.Flight),
-		fmt.Sprintf("altitude:  %f", a.Altitude),
+		a.altitudeString(),
 		fmt.Sprintf("seen:      %s", a.LastUpdate.Format(time.RFC3339)),
+		fmt.Sprintf("messages:  %d", a.Messages),
+	}
+
+	if ages := a.Seen.String(); ages != "" {
+		fields = append(fields,
+			fmt.Sprintf("ages:      %s", ages),
+		)
 	}
 
 	if a.GroundSpeed != nil {
@@ -68,12 +99,36 @@ func (a Aircraft) String() string {
 		)
 	}
 
+	if a.IAS != nil {
+		fields = append(fields,
+			fmt.Sprintf("IAS:       %f", *a.IAS),
+		)
+	}
+
+	if a.TAS != nil {
+		fields = append(fields,
+			fmt.Sprintf("TAS:       %f", *a.TAS),
+		)
+	}
+
 	if a.Track != nil {
 		fields = append(fields,
 			fmt.Sprintf("track:     %f", *a.Track),
 		)
 	}
 
+	if a.MagneticHeading != nil {
+		fields = append(fields,
+			fmt.Sprintf("heading:   %f", *a.MagneticHeading),
+		)
+	}
+
+	if a.GeometricAltitude != nil {
+		fields = append(fields,
+			fmt.Sprintf("GNSS alt:  %f", *a.GeometricAltitude),
+		)
+	}
+
 	if a.Position != nil {
 		fields = append(fields,
 			fmt.Sprintf("lat:      %f", a.Position.Latitude),
@@ -81,6 +136,36 @@ func (a Aircraft) String() string {
 		)
 	}
 
+	if a.TargetState != nil {
+		fields = append(fields,
+			fmt.Sprintf("target:    %s", a.TargetState),
+		)
+	}
+
+	if a.EmergencyState != EmergencyNone {
+		fields = append(fields,
+			fmt.Sprintf("emergency: %s", a.EmergencyState),
+		)
+	}
+
+	if a.ResolutionAdvisory != nil {
+		fields = append(fields,
+			fmt.Sprintf("TCAS:      %s", a.ResolutionAdvisory),
+		)
+	}
+
+	if a.ACAS != nil {
+		fields = append(fields,
+			fmt.Sprintf("ACAS:      %s", a.ACAS),
+		)
+	}
+
+	if a.Capability != nil {
+		fields = append(fields,
+			fmt.Sprintf("capable:   %s", a.Capability),
+		)
+	}
+
 	if a.CurrentOperation != "" {
 		fields = append(fields,
 			fmt.Sprintf("Operation: %s", a.CurrentOperation),
@@ -90,8 +175,21 @@ func (a Aircraft) String() string {
 	return strings.Join(fields, "\n")
 }
 
-// Emergency ...
+func (a Aircraft) altitudeString() string {
+	if a.OnGround {
+		return "altitude:  ground"
+	}
+
+	return fmt.Sprintf("altitude:  %f", a.Altitude)
+}
+
+// Emergency checks if the aircraft declared an emergency, either in the aircraft status (TC 28)
+// or with an emergency squawk.
 func (a Aircraft) Emergency() bool {
+	if a.EmergencyState != EmergencyNone {
+		return true
+	}
+
 	return (a.LastDownlinkFormat == 4 || a.LastDownlinkFormat == 5 || a.LastDownlinkFormat == 21) &&
 		(a.Identity == SquawkHijacker || a.Identity == SquawkRadioFailure || a.Identity == SquawkMayday)
 }
@@ -104,7 +202,7 @@ func (a Aircraft) Alert() bool {
 
 // Ground ...
 func (a Aircraft) Ground() bool {
-	return (a.LastDownlinkFormat == 4 || a.LastDownlinkFormat == 5 || a.LastDownlinkFormat == 21) &&
+	return a.OnGround || (a.LastDownlinkFormat == 4 || a.LastDownlinkFormat == 5 || a.LastDownlinkFormat == 21) &&
 		(a.LastFlightStatus == 1 || a.LastFlightStatus == 3)
 }
 
@@ -1,48 +1,46 @@
 package model
 
+import "github.com/landru29/adsb1090/internal/modeac"
+
+// AltitudeNotAvailable is the decoded altitude of the frames without any.
+const AltitudeNotAvailable = -1.0
+
+// altitudeFrom13Bits decodes the 13 bits AC field; AltitudeNotAvailable when not available.
+//
+//	┏━━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━━┯━━━┯━━━━┯━━━┯━━━━┯━━━━┯━━━━┯━━━━┓
+//	┃ C1 | A1 | C2 | A2 | C4 | A4 | M | B1 | Q | B2 | D2 | B4 | D4 ┃
+//	┗━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━━┷━━━┷━━━━┷━━━┷━━━━┷━━━━┷━━━━┷━━━━┛
 func altitudeFrom13Bits(altitudeData uint16) float64 {
-	bitC1 := (altitudeData & 0x1000) >> 12 //nolint: gomnd
-	bitA1 := (altitudeData & 0x0800) >> 11 //nolint: gomnd
-	bitC2 := (altitudeData & 0x0400) >> 10 //nolint: gomnd
-	bitA2 := (altitudeData & 0x0200) >> 9  //nolint: gomnd
-	bitC4 := (altitudeData & 0x0100) >> 8  //nolint: gomnd
-	bitA4 := (altitudeData & 0x0080) >> 7  //nolint: gomnd
-	bitM := (altitudeData & 0x0040) >> 6   //nolint: gomnd
-	bitB1 := (altitudeData & 0x0020) >> 5  //nolint: gomnd
-	bitQ := (altitudeData & 0x0010) >> 4   //nolint: gomnd
-	bitB2 := (altitudeData & 0x0008) >> 3  //nolint: gomnd
-	bitD2 := (altitudeData & 0x0004) >> 2  //nolint: gomnd
-	bitB4 := (altitudeData & 0x0002) >> 1  //nolint: gomnd
-	bitD4 := altitudeData & 0x0001         //nolint: gomnd
-
-	if bitC1 == 0 &&
-		bitA1 == 0 &&
-		bitC2 == 0 &&
-		bitA2 == 0 &&
-		bitC4 == 0 &&
-		bitA4 == 0 &&
-		bitM == 0 &&
-		bitB1 == 0 &&
-		bitQ == 0 &&
-		bitB2 == 0 &&
-		bitD2 == 0 &&
-		bitB4 == 0 &&
-		bitD4 == 0 {
-		return -1
+	if altitudeData == 0 {
+		return AltitudeNotAvailable
 	}
 
+	bitM := (altitudeData & 0x0040) >> 6 //nolint: gomnd
+	bitQ := (altitudeData & 0x0010) >> 4 //nolint: gomnd
+
 	if bitM == 1 {
-		meters := (altitudeData & 0x27) + ((altitudeData & 0xf80) >> 1) //nolint: gomnd
+		meters := (altitudeData & 0x3f) + ((altitudeData & 0x1f80) >> 1) //nolint: gomnd
 
 		return float64(meters) * meterToFeet
 	}
 
 	if bitQ == 1 {
+		bitB1 := (altitudeData & 0x0020) >> 5                                          //nolint: gomnd
 		feets := (altitudeData & 0x0f) + (bitB1 << 4) + ((altitudeData & 0x1f80) >> 2) //nolint: gomnd
 
 		return float64(feets)*25 - 1000 //nolint: gomnd
 	}
 
-	// bitM == 0, bitQ == 0 not implemented.
-	return -1
+	// Gillham code, with 100 feet increments.
+	feets, err := modeac.FromField(altitudeData).Altitude()
+	if err != nil {
+		return AltitudeNotAvailable
+	}
+
+	return float64(feets)
+}
+
+// altitudeFrom12Bits decodes the 12 bits altitude of the ADS-B position, the AC field without the M bit.
+func altitudeFrom12Bits(altitudeData uint16) float64 {
+	return altitudeFrom13Bits(((altitudeData & 0x0fc0) << 1) | (altitudeData & 0x003f)) //nolint: gomnd
 }
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType is the type of an aircraft event.
+type EventType string
+
+const (
+	// EventResolutionAdvisory is when a resolution advisory is issued, updated or terminated.
+	EventResolutionAdvisory EventType = "resolutionAdvisory"
+)
+
+// Event is a notable change of an aircraft state.
+type Event struct {
+	Type               EventType           `json:"type"`
+	Addr               ICAOAddr            `json:"icao"`
+	Identification     string              `json:"ident,omitempty"`
+	Date               time.Time           `json:"date"`
+	ResolutionAdvisory *ResolutionAdvisory `json:"resolutionAdvisory,omitempty"`
+}
+
+// String implements the Stringer interface.
+func (e Event) String() string {
+	output := fmt.Sprintf("%s %s", e.Type, e.Addr)
+
+	if e.Identification != "" {
+		output += " " + e.Identification
+	}
+
+	if e.ResolutionAdvisory != nil {
+		output += ": " + e.ResolutionAdvisory.String()
+	}
+
+	return output
+}
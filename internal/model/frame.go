@@ -0,0 +1,65 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	maxSignal = 255
+
+	// fullScaleMagnitude is the magnitude of a full scale sample (I or Q at 128), as computed by the demodulators.
+	fullScaleMagnitude = 128 * 360
+)
+
+// Frame is a Mode S frame, with its reception data.
+type Frame struct {
+	ModeS ModeS
+	// Timestamp is the reception counter, running at 12 MHz.
+	Timestamp uint64
+	// Signal is the signal level (0-255).
+	Signal uint8
+	// ReceivedAt is the wall-clock reception time.
+	ReceivedAt time.Time
+	// RSSI is the signal level in dBFS; 0 when unknown.
+	RSSI float64
+	// CorrectedBits is the number of bits repaired with the CRC.
+	CorrectedBits int
+}
+
+// NewFrame creates a frame received now, without any signal information.
+func NewFrame(data []byte) Frame {
+	return Frame{
+		ModeS:      ModeS(data),
+		ReceivedAt: time.Now(),
+	}
+}
+
+// RSSIFromMagnitude is the signal level in dBFS of a demodulated magnitude.
+func RSSIFromMagnitude(magnitude uint16) float64 {
+	if magnitude == 0 {
+		return 0
+	}
+
+	return 20 * math.Log10(float64(magnitude)/fullScaleMagnitude) //nolint: gomnd
+}
+
+// RSSIFromSignal is the signal level in dBFS of a Beast signal level.
+func RSSIFromSignal(signal uint8) float64 {
+	if signal == 0 {
+		return 0
+	}
+
+	return 20 * math.Log10(float64(signal)/maxSignal) //nolint: gomnd
+}
+
+// SignalFromRSSI is the Beast signal level of a signal level in dBFS.
+func SignalFromRSSI(rssi float64) uint8 {
+	if rssi == 0 {
+		return 0
+	}
+
+	signal := math.Round(math.Pow(10, rssi/20) * maxSignal) //nolint: gomnd
+
+	return uint8(math.Max(1, math.Min(signal, maxSignal)))
+}
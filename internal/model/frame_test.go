@@ -0,0 +1,21 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalLevel(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, -6.02, model.RSSIFromMagnitude(64*360), 0.01)
+	assert.InDelta(t, -6.06, model.RSSIFromSignal(127), 0.01)
+	assert.Equal(t, uint8(127), model.SignalFromRSSI(model.RSSIFromSignal(127)))
+	assert.Equal(t, uint8(1), model.SignalFromRSSI(-80))
+
+	assert.Zero(t, model.RSSIFromMagnitude(0))
+	assert.Zero(t, model.RSSIFromSignal(0))
+	assert.Zero(t, model.SignalFromRSSI(0))
+}
@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPositionStaleness       = time.Minute
+	defaultAltitudeStaleness       = time.Minute
+	defaultVelocityStaleness       = time.Minute
+	defaultIdentificationStaleness = time.Minute * 5
+	defaultSquawkStaleness         = time.Minute * 5
+	defaultOperationalStaleness    = time.Minute * 5
+)
+
+// Seen is the reception information of an aircraft field.
+type Seen struct {
+	At       time.Time `json:"at"`              /* Reception date of the last value. */
+	Age      float64   `json:"age"`             /* Seconds elapsed since the reception, at the serialization. */
+	Messages uint64    `json:"messages"`        /* Number of messages carrying the field. */
+	Stale    bool      `json:"stale,omitempty"` /* The value is older than the staleness threshold. */
+}
+
+// FieldsSeen is the reception information of the aircraft fields; nil when the field was never received.
+type FieldsSeen struct {
+	Position          *Seen `json:"position,omitempty"`
+	Altitude          *Seen `json:"altitude,omitempty"`
+	Velocity          *Seen `json:"velocity,omitempty"`
+	Identification    *Seen `json:"identification,omitempty"`
+	Squawk            *Seen `json:"squawk,omitempty"`
+	OperationalStatus *Seen `json:"operationalStatus,omitempty"`
+}
+
+// String implements the Stringer interface.
+func (s Seen) String() string {
+	if s.Stale {
+		return fmt.Sprintf("%.1fs (stale)", s.Age)
+	}
+
+	return fmt.Sprintf("%.1fs", s.Age)
+}
+
+// String implements the Stringer interface.
+func (f FieldsSeen) String() string {
+	fields := []string{}
+
+	for _, field := range []struct {
+		name string
+		seen *Seen
+	}{
+		{name: "position", seen: f.Position},
+		{name: "altitude", seen: f.Altitude},
+		{name: "velocity", seen: f.Velocity},
+		{name: "identification", seen: f.Identification},
+		{name: "squawk", seen: f.Squawk},
+		{name: "status", seen: f.OperationalStatus},
+	} {
+		if field.seen != nil {
+			fields = append(fields, fmt.Sprintf("%s %s", field.name, field.seen))
+		}
+	}
+
+	return strings.Join(fields, ", ")
+}
+
+// Staleness is the maximum age of the aircraft fields; a zero duration never expires.
+type Staleness struct {
+	Position          time.Duration
+	Altitude          time.Duration
+	Velocity          time.Duration
+	Identification    time.Duration
+	Squawk            time.Duration
+	OperationalStatus time.Duration
+	Omit              bool /* Stale fields are removed instead of flagged. */
+}
+
+// DefaultStaleness is the default maximum age of the aircraft fields.
+func DefaultStaleness() Staleness {
+	return Staleness{
+		Position:          defaultPositionStaleness,
+		Altitude:          defaultAltitudeStaleness,
+		Velocity:          defaultVelocityStaleness,
+		Identification:    defaultIdentificationStaleness,
+		Squawk:            defaultSquawkStaleness,
+		OperationalStatus: defaultOperationalStaleness,
+	}
+}
+
+// Fresh is a copy of the aircraft with the age of each field at the specified date.
+// The stale fields are flagged, or removed when the staleness requires it.
+func (a Aircraft) Fresh(staleness Staleness, now time.Time) Aircraft {
+	output := a
+
+	for _, field := range []struct {
+		seen   **Seen
+		maxAge time.Duration
+		clear  func()
+	}{
+		{
+			seen:   &output.Seen.Position,
+			maxAge: staleness.Position,
+			clear: func() {
+				output.Position = nil
+			},
+		},
+		{
+			seen:   &output.Seen.Altitude,
+			maxAge: staleness.Altitude,
+			clear: func() {
+				output.Altitude = 0
+				output.GeometricAltitude = nil
+			},
+		},
+		{
+			seen:   &output.Seen.Velocity,
+			maxAge: staleness.Velocity,
+			clear: func() {
+				output.GroundSpeed = nil
+				output.AirSpeed = nil
+				output.Track = nil
+				output.MagneticHeading = nil
+				output.IAS = nil
+				output.TAS = nil
+				output.GeometricAltitude = nil
+				output.VerticalRate = 0
+			},
+		},
+		{
+			seen:   &output.Seen.Identification,
+			maxAge: staleness.Identification,
+			clear: func() {
+				output.Identification = ""
+				output.Category = ""
+			},
+		},
+		{
+			seen:   &output.Seen.Squawk,
+			maxAge: staleness.Squawk,
+			clear: func() {
+				output.Identity = 0
+			},
+		},
+		{
+			seen:   &output.Seen.OperationalStatus,
+			maxAge: staleness.OperationalStatus,
+			clear: func() {
+				output.OperationalStatus = nil
+				output.CurrentOperation = ""
+			},
+		},
+	} {
+		if *field.seen == nil {
+			continue
+		}
+
+		seen := **field.seen
+		*field.seen = &seen
+
+		age := now.Sub(seen.At)
+		seen.Age = age.Seconds()
+		seen.Stale = field.maxAge > 0 && age > field.maxAge
+
+		if seen.Stale && staleness.Omit {
+			field.clear()
+		}
+	}
+
+	return output
+}
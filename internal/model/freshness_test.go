@@ -0,0 +1,67 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	aircraft := model.Aircraft{
+		Identification: "KLM1017",
+		Altitude:       38000,
+		Position:       &model.Position{Latitude: 52.2572, Longitude: 3.9194},
+		Seen: model.FieldsSeen{
+			Position:       &model.Seen{At: now.Add(-90 * time.Second), Messages: 2},
+			Altitude:       &model.Seen{At: now.Add(-2 * time.Second), Messages: 5},
+			Identification: &model.Seen{At: now.Add(-90 * time.Second), Messages: 1},
+		},
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		t.Parallel()
+
+		fresh := aircraft.Fresh(model.DefaultStaleness(), now)
+
+		require.NotNil(t, fresh.Position)
+		assert.True(t, fresh.Seen.Position.Stale)
+		assert.InDelta(t, 90.0, fresh.Seen.Position.Age, 0.001)
+		assert.False(t, fresh.Seen.Altitude.Stale)
+		assert.False(t, fresh.Seen.Identification.Stale)
+		assert.Nil(t, fresh.Seen.Velocity)
+
+		assert.Zero(t, aircraft.Seen.Position.Age)
+		assert.Equal(t, "position 90.0s (stale), altitude 2.0s, identification 90.0s", fresh.Seen.String())
+	})
+
+	t.Run("omit", func(t *testing.T) {
+		t.Parallel()
+
+		staleness := model.DefaultStaleness()
+		staleness.Omit = true
+
+		fresh := aircraft.Fresh(staleness, now)
+
+		assert.Nil(t, fresh.Position)
+		assert.InDelta(t, 38000.0, fresh.Altitude, 0.1)
+		assert.Equal(t, "KLM1017", fresh.Identification)
+
+		require.NotNil(t, aircraft.Position)
+	})
+
+	t.Run("never stale", func(t *testing.T) {
+		t.Parallel()
+
+		fresh := aircraft.Fresh(model.Staleness{Omit: true}, now)
+
+		assert.NotNil(t, fresh.Position)
+		assert.False(t, fresh.Seen.Position.Stale)
+	})
+}
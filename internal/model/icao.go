@@ -12,16 +12,80 @@ const (
 	ErrWrongICAO errors.Error = "wrong ICAO address"
 
 	minICAOsizeJSON = 2
+
+	// nonICAOPrefix is the prefix of the non-ICAO addresses in their text form.
+	nonICAOPrefix = "~"
+
+	addressMask      = 0xffffff
+	addressTypeShift = 24
+)
+
+// AddressType is the type of an aircraft address.
+type AddressType uint8
+
+const (
+	// AddressTypeICAO is the ICAO 24 bits aircraft address.
+	AddressTypeICAO AddressType = 0
+	// AddressTypeNonICAO is an anonymous, ground vehicle, fixed obstruction or TIS-B track file address.
+	AddressTypeNonICAO AddressType = 1
 )
 
-// ICAOAddr is the ICAO aircraft address.
+// String implements the Stringer interface.
+func (t AddressType) String() string {
+	if t == AddressTypeNonICAO {
+		return "non-ICAO"
+	}
+
+	return "ICAO"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t AddressType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *AddressType) UnmarshalText(data []byte) error {
+	*t = AddressTypeICAO
+
+	if string(data) == AddressTypeNonICAO.String() {
+		*t = AddressTypeNonICAO
+	}
+
+	return nil
+}
+
+// ICAOAddr is the aircraft address: the 24 bits address, and its type in the upper bits.
+// Addresses of different types never collide, so they can be used as keys.
 type ICAOAddr uint32
 
-// ParseICAOAddr parses from hexadecimal.
+// NewAddress is the address of the specified type.
+func NewAddress(address uint32, addressType AddressType) ICAOAddr {
+	return ICAOAddr(address&addressMask | uint32(addressType)<<addressTypeShift)
+}
+
+// ParseICAOAddr parses from hexadecimal; the non-ICAO addresses are prefixed with '~'.
 func ParseICAOAddr(str string) (ICAOAddr, error) {
+	addressType := AddressTypeICAO
+
+	if strings.HasPrefix(str, nonICAOPrefix) {
+		addressType = AddressTypeNonICAO
+		str = strings.TrimPrefix(str, nonICAOPrefix)
+	}
+
 	value, err := strconv.ParseUint(str, 16, 32)
 
-	return ICAOAddr(value), err
+	return NewAddress(uint32(value), addressType), err
+}
+
+// AddressType is the type of the address.
+func (a ICAOAddr) AddressType() AddressType {
+	return AddressType(a >> addressTypeShift)
+}
+
+// Number is the 24 bits address, without its type.
+func (a ICAOAddr) Number() uint32 {
+	return uint32(a) & addressMask
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -44,7 +108,13 @@ func (a *ICAOAddr) UnmarshalJSON(data []byte) error {
 
 // String implements the Stringer interface.
 func (a ICAOAddr) String() string {
-	return strings.ToUpper(strconv.FormatUint(uint64(a), 16))
+	output := strings.ToUpper(strconv.FormatUint(uint64(a.Number()), 16))
+
+	if a.AddressType() == AddressTypeNonICAO {
+		return nonICAOPrefix + output
+	}
+
+	return output
 }
 
 // Set implements the pflag.Value interface.
@@ -18,6 +18,11 @@ func TestMarshalICAO(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, `"ABCDEF"`, string(out))
+
+	out, err = json.Marshal(model.NewAddress(0xabcdef, model.AddressTypeNonICAO))
+	require.NoError(t, err)
+
+	assert.Equal(t, `"~ABCDEF"`, string(out))
 }
 
 func TestUnmarshalICAO(t *testing.T) {
@@ -34,6 +39,19 @@ func TestUnmarshalICAO(t *testing.T) {
 		assert.Equal(t, model.ICAOAddr(0x123456), icao)
 	})
 
+	t.Run("non-ICAO", func(t *testing.T) {
+		t.Parallel()
+
+		var icao model.ICAOAddr
+
+		err := json.Unmarshal([]byte(`"~123456"`), &icao)
+		require.NoError(t, err)
+
+		assert.Equal(t, model.AddressTypeNonICAO, icao.AddressType())
+		assert.Equal(t, uint32(0x123456), icao.Number())
+		assert.NotEqual(t, model.ICAOAddr(0x123456), icao)
+	})
+
 	t.Run("empty value", func(t *testing.T) {
 		t.Parallel()
 
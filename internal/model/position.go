@@ -1,12 +1,28 @@
 package model
 
 import (
+	"math"
+
 	"github.com/landru29/adsb1090/internal/compactposition"
 	"github.com/landru29/adsb1090/internal/errors"
 )
 
 const (
 	errFrameOddEven errors.Error = "frames must be odd and even"
+
+	// ErrOutOfRange is when a decoded position is too far from the receiver.
+	ErrOutOfRange errors.Error = "position out of the receiver range"
+
+	earthRadius = 6371000.0 /* meters */
+
+	// localAirborneRange is the maximum distance to the reference for the local decoding of airborne positions.
+	localAirborneRange = 180 * 1852.0 /* 180 NM in meters */
+
+	// localSurfaceRange is the maximum distance to the reference for the local decoding of surface positions.
+	localSurfaceRange = 45 * 1852.0 /* 45 NM in meters */
+
+	// surfaceAmbiguity is the span of the surface positions encoding, in degrees.
+	surfaceAmbiguity = 90.0
 )
 
 // Position is a GPS position.
@@ -15,6 +31,47 @@ type Position struct {
 	Latitude  float64 `json:"lat"`
 }
 
+// Distance is the great circle distance in meters.
+func (p Position) Distance(other Position) float64 {
+	lat1 := p.Latitude * math.Pi / 180.0     //nolint: gomnd
+	lat2 := other.Latitude * math.Pi / 180.0 //nolint: gomnd
+	deltaLat := lat2 - lat1
+	deltaLng := (other.Longitude - p.Longitude) * math.Pi / 180.0 //nolint: gomnd
+
+	haversine := math.Pow(math.Sin(deltaLat/2), 2) + //nolint: gomnd
+		math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(deltaLng/2), 2) //nolint: gomnd
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(haversine)) //nolint: gomnd
+}
+
+// Receiver is the location of the receiver, used as reference to decode single position frames.
+type Receiver struct {
+	Position Position
+	MaxRange float64 /* meters */
+}
+
+// InRange checks if the position can be received.
+func (r Receiver) InRange(position Position) bool {
+	return r.MaxRange <= 0 || r.Position.Distance(position) <= r.MaxRange
+}
+
+// DecodePosition decodes the position of a single frame, using the receiver as reference.
+func (r Receiver) DecodePosition(current Positionner, surface bool) (*Position, error) {
+	position := DecodeLocalPosition(current, r.Position, surface)
+
+	maxRange := localAirborneRange
+	if surface {
+		maxRange = localSurfaceRange
+	}
+
+	distance := r.Position.Distance(*position)
+	if distance > maxRange || !r.InRange(*position) {
+		return nil, ErrOutOfRange
+	}
+
+	return position, nil
+}
+
 // Positionner is a frame containing position informations.
 type Positionner interface {
 	// EncodedLatitude is the encoded latitude.
@@ -35,7 +92,11 @@ func DecodePosition(current Positionner, other Positionner) (*Position, error) {
 
 	if current.OddFrame() {
 		latOdd, latEven := compactposition.DecodeLatitude(current.EncodedLatitude(), other.EncodedLatitude())
-		lngOdd, _ := compactposition.DecodeLongitude(current.EncodedLongitude(), other.EncodedLongitude(), latOdd, latEven)
+
+		lngOdd, _, err := compactposition.DecodeLongitude(current.EncodedLongitude(), other.EncodedLongitude(), latOdd, latEven)
+		if err != nil {
+			return nil, err
+		}
 
 		return &Position{
 			Latitude:  latOdd,
@@ -44,10 +105,86 @@ func DecodePosition(current Positionner, other Positionner) (*Position, error) {
 	}
 
 	latOdd, latEven := compactposition.DecodeLatitude(other.EncodedLatitude(), current.EncodedLatitude())
-	_, lngEven := compactposition.DecodeLongitude(other.EncodedLongitude(), current.EncodedLongitude(), latOdd, latEven)
+
+	_, lngEven, err := compactposition.DecodeLongitude(other.EncodedLongitude(), current.EncodedLongitude(), latOdd, latEven)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Position{
 		Latitude:  latEven,
 		Longitude: lngEven,
 	}, nil
 }
+
+// DecodeSurfacePosition decodes the current surface position with another frame.
+// Surface positions are ambiguous by 90°: the hemisphere and the quadrant are the ones of the reference.
+func DecodeSurfacePosition(current Positionner, other Positionner, reference Position) (*Position, error) {
+	if current.OddFrame() == other.OddFrame() {
+		return nil, errFrameOddEven
+	}
+
+	odd, even := current, other
+	if !current.OddFrame() {
+		odd, even = other, current
+	}
+
+	latOdd, latEven := compactposition.DecodeSurfaceLatitude(odd.EncodedLatitude(), even.EncodedLatitude())
+
+	if math.Abs(latOdd-surfaceAmbiguity-reference.Latitude) < math.Abs(latOdd-reference.Latitude) {
+		latOdd -= surfaceAmbiguity
+		latEven -= surfaceAmbiguity
+	}
+
+	lngOdd, lngEven, err := compactposition.DecodeSurfaceLongitude(
+		odd.EncodedLongitude(),
+		even.EncodedLongitude(),
+		latOdd,
+		latEven,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &Position{
+		Latitude:  latEven,
+		Longitude: lngEven,
+	}
+
+	if current.OddFrame() {
+		output.Latitude = latOdd
+		output.Longitude = lngOdd
+	}
+
+	output.Longitude += surfaceAmbiguity * math.Round((reference.Longitude-output.Longitude)/surfaceAmbiguity)
+
+	switch {
+	case output.Longitude > 180: //nolint: gomnd
+		output.Longitude -= 360.0
+	case output.Longitude <= -180: //nolint: gomnd
+		output.Longitude += 360.0
+	}
+
+	return output, nil
+}
+
+// DecodeLocalPosition decodes the position of a single frame, with a reference position less than half a zone away.
+func DecodeLocalPosition(current Positionner, reference Position, surface bool) *Position {
+	latitude := compactposition.DecodeLocalLatitude(
+		current.EncodedLatitude(),
+		current.OddFrame(),
+		surface,
+		reference.Latitude,
+	)
+
+	return &Position{
+		Latitude: latitude,
+		Longitude: compactposition.DecodeLocalLongitude(
+			current.EncodedLongitude(),
+			current.OddFrame(),
+			surface,
+			latitude,
+			reference.Longitude,
+		),
+	}
+}
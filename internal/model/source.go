@@ -0,0 +1,51 @@
+package model
+
+import "fmt"
+
+// Source is the origin of the aircraft data.
+type Source uint8
+
+const (
+	// SourceModeS is the Mode S replies.
+	SourceModeS Source = 0
+	// SourceADSB is the extended squitters broadcasted by the aircraft.
+	SourceADSB Source = 1
+	// SourceTISB is the traffic information broadcasted by the ground stations, from the radar tracks.
+	SourceTISB Source = 2
+	// SourceADSR is the ADS-B data rebroadcasted by the ground stations, from other data links.
+	SourceADSR Source = 3
+)
+
+// String implements the Stringer interface.
+func (s Source) String() string {
+	switch s {
+	case SourceModeS:
+		return "Mode S"
+	case SourceADSB:
+		return "ADS-B"
+	case SourceTISB:
+		return "TIS-B"
+	case SourceADSR:
+		return "ADS-R"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Source) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Source) UnmarshalText(data []byte) error {
+	for value := SourceModeS; value <= SourceADSR; value++ {
+		if value.String() == string(data) {
+			*s = value
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: source %s", ErrUnsupportedFormat, data)
+}
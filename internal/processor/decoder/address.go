@@ -0,0 +1,82 @@
+package decoder
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/binary"
+	"github.com/landru29/adsb1090/internal/database"
+	"github.com/landru29/adsb1090/internal/model"
+)
+
+// defaultAddressLifetime is how long a confirmed address validates the address/parity frames.
+const defaultAddressLifetime = time.Minute
+
+// addressFilter is the set of the recently confirmed addresses.
+// The address/parity frames (DF0, 4, 5, 16, 20, 21, 24) give an address whatever the bit errors;
+// they are only trusted when the aircraft was confirmed by a frame with a plain parity (DF11, 17, 18).
+// The military extended squitters (DF19), without any parity check, are filtered the same way.
+type addressFilter struct {
+	confirmed  *database.ElementStorage[model.ICAOAddr, time.Time]
+	lifetime   time.Duration
+	rejections *atomic.Uint64
+}
+
+func newAddressFilter(ctx context.Context, lifetime time.Duration) *addressFilter {
+	return &addressFilter{
+		confirmed: database.NewElementStorage[model.ICAOAddr, time.Time](
+			ctx,
+			database.ElementWithLifetime[model.ICAOAddr, time.Time](lifetime),
+		),
+		lifetime:   lifetime,
+		rejections: &atomic.Uint64{},
+	}
+}
+
+// confirm records an address checked by its parity.
+func (f *addressFilter) confirm(addr model.ICAOAddr, now time.Time) {
+	f.confirmed.Add(addr, now)
+}
+
+// accept checks if the address was confirmed recently, and counts the rejections.
+func (f *addressFilter) accept(addr model.ICAOAddr, now time.Time) bool {
+	if confirmedAt := f.confirmed.Element(addr); confirmedAt != nil && now.Sub(*confirmedAt) <= f.lifetime {
+		return true
+	}
+
+	f.rejections.Add(1)
+
+	return false
+}
+
+// confirming checks if the frame has a plain parity, checked against its address.
+// The repaired frames, and the all-call replies to an interrogator (IID other than 0), may be noise: they do not confirm.
+// The DF18 messages only confirm with a control field of the decoded messages.
+func confirming(modes model.ModeS, correctedBits int) bool {
+	if correctedBits > 0 {
+		return false
+	}
+
+	switch modes.DownlinkFormat() { //nolint: exhaustive
+	case model.DownlinkFormatAllCallReply:
+		return binary.Syndrome(modes) == 0
+	case model.DownlinkFormatExtendedSquitter:
+		return true
+	case model.DownlinkFormatExtendedSquitterNonTransponder:
+		squitter := model.ExtendedSquitter{ModeS: modes}
+
+		switch squitter.ControlField() { //nolint: exhaustive
+		case model.ControlFieldADSB,
+			model.ControlFieldADSBNonICAO,
+			model.ControlFieldFineTISB,
+			model.ControlFieldFineTISBNonICAO,
+			model.ControlFieldADSR:
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
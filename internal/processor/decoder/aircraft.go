@@ -2,223 +2,529 @@ package decoder
 
 import (
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/landru29/adsb1090/internal/aircraftdb"
 	"github.com/landru29/adsb1090/internal/model"
 )
 
-type extendedSquitter struct {
-	Identification         []model.Identification
-	OperationStatus        []model.OperationStatus
-	SurfacePosition        []model.SurfacePosition
-	AirbornePosition       []model.AirbornePosition
-	AirborneVelocity       []model.AirborneVelocity
-	lastPositionIsAirborne bool
-}
-
-func buildAircraft(log *slog.Logger, squitters []model.QualifiedMessage, ref aircraftdb.Entry) *model.Aircraft {
-	aircraft := model.Aircraft{
-		Registration:     ref.Registration,
-		ManufacturerName: ref.ManufacturerName,
-		Model:            ref.Model,
-		Operator:         ref.Operator,
-		Owner:            ref.Owner,
-		Built:            ref.Built,
-	}
-
-	lastSquitter := squitters[len(squitters)-1]
-
-	aircraft.LastDownlinkFormat = lastSquitter.DownlinkFormat()
-	if lastExtendedSquitter, ok := lastSquitter.(model.ExtendedSquitter); ok {
-		aircraft.LastType = lastExtendedSquitter.TypeCode()
-		aircraft.LastSubType = lastExtendedSquitter.SubTypeCode()
-	}
-
-	extendedSquitters := extendedSquitter{}
-
-	for _, genericSquitter := range squitters {
-		if extendedSquitter, ok := genericSquitter.(model.ExtendedSquitter); ok {
-			decoded, err := extendedSquitter.Decode()
-			if err != nil {
-				continue
-			}
-
-			switch val := decoded.(type) {
-			case model.Identification:
-				extendedSquitters.Identification = append(extendedSquitters.Identification, val)
-			case model.OperationStatus:
-				extendedSquitters.OperationStatus = append(extendedSquitters.OperationStatus, val)
-			case model.SurfacePosition:
-				extendedSquitters.SurfacePosition = append(extendedSquitters.SurfacePosition, val)
-				extendedSquitters.lastPositionIsAirborne = false
-			case model.AirbornePosition:
-				extendedSquitters.AirbornePosition = append(extendedSquitters.AirbornePosition, val)
-				extendedSquitters.lastPositionIsAirborne = true
-			case model.AirborneVelocity:
-				extendedSquitters.AirborneVelocity = append(extendedSquitters.AirborneVelocity, val)
-			}
-
-			continue
-		}
+// field is a part of the aircraft state, with its own update date.
+type field uint8
+
+const (
+	fieldIdentification field = iota
+	fieldPosition
+	fieldAltitude
+	fieldVelocity
+	fieldIdentity
+	fieldFlightStatus
+	fieldOperationalStatus
+	fieldTargetState
+	fieldEmergency
+	fieldResolutionAdvisory
+	fieldCommB
+	fieldACAS
+	fieldADSBVelocity /* The velocity from the extended squitters, preferred to the Comm-B one. */
+	fieldCount
+)
 
-		if longMessage, ok := genericSquitter.(model.LongMessage); ok {
-			processLongMessage(log, &aircraft, longMessage)
+// adsbVelocityPrecedence is how long the ADS-B velocity is kept against the one inferred from the Comm-B registers.
+const adsbVelocityPrecedence = 30 * time.Second
 
-			continue
-		}
+// aircraftState is the state of an aircraft, updated by each new message.
+type aircraftState struct {
+	mutex     sync.Mutex
+	aircraft  model.Aircraft
+	updatedAt [fieldCount]time.Time
+	messages  [fieldCount]uint64
+	events    []model.Event
+}
+
+func newAircraftState(addr model.ICAOAddr, ref *aircraftdb.Entry) *aircraftState {
+	state := &aircraftState{
+		aircraft: model.Aircraft{
+			IcaoAddress: addr,
+			Addr:        addr,
+			AddressType: addr.AddressType(),
+		},
+	}
+
+	if ref != nil {
+		state.aircraft.Registration = ref.Registration
+		state.aircraft.ManufacturerName = ref.ManufacturerName
+		state.aircraft.Model = ref.Model
+		state.aircraft.Operator = ref.Operator
+		state.aircraft.Owner = ref.Owner
+		state.aircraft.Built = ref.Built
+		state.aircraft.ReferenceFound = true
+	}
+
+	return state
+}
+
+// update applies a new message to the state, and returns a copy of the aircraft with the raised events.
+func (s *aircraftState) update(
+	log *slog.Logger,
+	squitter model.QualifiedMessage,
+	positions *positionDecoder,
+	frame model.Frame,
+) (model.Aircraft, []model.Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := frame.ReceivedAt
+	s.events = nil
+
+	if frame.RSSI != 0 {
+		s.aircraft.RSSI = frame.RSSI
+	}
+
+	s.aircraft.LastDownlinkFormat = squitter.DownlinkFormat()
+	s.aircraft.LastType = 0
+	s.aircraft.LastSubType = 0
+
+	switch message := squitter.(type) {
+	case model.ExtendedSquitter:
+		s.aircraft.LastType = message.TypeCode()
+		s.aircraft.LastSubType = message.SubTypeCode()
 
-		if shortMessage, ok := genericSquitter.(model.ShortMessage); ok {
-			processShortMessage(log, &aircraft, shortMessage)
+		if message.DownlinkFormat() == model.DownlinkFormatExtendedSquitter {
+			capability := message.TransponderCapability()
 
-			continue
+			s.aircraft.Capability = &capability
 		}
+
+		s.processExtendedSquitter(log, message, positions, now)
+	case model.LongMessage:
+		s.processLongMessage(log, message, now)
+	case model.ShortMessage:
+		s.processShortMessage(log, message, now)
 	}
 
-	processExtendedSquitter(log, &aircraft, extendedSquitters)
+	s.aircraft.LastUpdate = now
+	s.aircraft.Messages++
+	s.aircraft.Seen = model.FieldsSeen{
+		Position:          s.seen(fieldPosition),
+		Altitude:          s.seen(fieldAltitude),
+		Velocity:          s.seen(fieldVelocity),
+		Identification:    s.seen(fieldIdentification),
+		Squawk:            s.seen(fieldIdentity),
+		OperationalStatus: s.seen(fieldOperationalStatus),
+	}
 
-	return &aircraft
+	return s.aircraft, s.events
 }
 
-func processShortMessage(log *slog.Logger, aircraft *model.Aircraft, message model.ShortMessage) { //nolint: revive,unparam,lll,whitespace,wsl
+// touch sets the update date of a field, and counts the message.
+func (s *aircraftState) touch(now time.Time, fields ...field) {
+	for _, current := range fields {
+		s.updatedAt[current] = now
+		s.messages[current]++
+	}
+}
+
+// seen is the reception information of a field; nil when the field was never received.
+func (s *aircraftState) seen(current field) *model.Seen {
+	if s.messages[current] == 0 {
+		return nil
+	}
+
+	return &model.Seen{
+		At:       s.updatedAt[current],
+		Messages: s.messages[current],
+	}
+}
+
+func (s *aircraftState) processShortMessage(log *slog.Logger, message model.ShortMessage, now time.Time) { //nolint: revive,unparam,lll,whitespace,wsl
 	switch message.DownlinkFormat() { //nolint: exhaustive
 	case model.DownlinkFormatAllCallReply:
-		// nothing to do
+		allCallReply := model.AllCallReply{ShortMessage: message}
+
+		capability := allCallReply.Capability()
+
+		s.aircraft.Capability = &capability
+
+		log.Debug("all-call reply", "interrogator", allCallReply.InterrogatorID(), "capability", capability.String())
 	case model.DownlinkFormatShortAirAirSurveillance:
-		// nothing to do
+		surveillance := model.ShortAirAirSurveillance{ShortMessage: message}
+
+		s.altitude(surveillance.Altitude(), now)
+		s.aircraft.OnGround = surveillance.OnGround()
+
+		s.acas(surveillance.SensitivityLevel(), surveillance.ReplyInformation(), surveillance.CrossLink())
+
+		s.touch(now, fieldACAS)
 	case model.DownlinkFormatAltitudeReply:
 		surveillanceReplyWithAltitude := model.SurveillanceReplyWithAltitude{ShortMessage: message}
 
 		flightStatus := surveillanceReplyWithAltitude.FlightStatus()
 
-		aircraft.Altitude = surveillanceReplyWithAltitude.Altitude()
+		s.altitude(surveillanceReplyWithAltitude.Altitude(), now)
+
+		s.aircraft.FlightStatus = &flightStatus
 
-		aircraft.FlightStatus = &flightStatus
+		s.touch(now, fieldFlightStatus)
 
 	case model.DownlinkFormatIdentityReply:
 		surveillanceReplyWithIdentification := model.SurveillanceReplyWithIdentification{ShortMessage: message}
 
 		flightStatus := surveillanceReplyWithIdentification.FlightStatus()
 
-		aircraft.Identity = surveillanceReplyWithIdentification.Identity()
+		s.aircraft.Identity = surveillanceReplyWithIdentification.Identity()
 
-		aircraft.FlightStatus = &flightStatus
-	}
+		s.aircraft.FlightStatus = &flightStatus
 
-	aircraft.LastUpdate = time.Now()
+		s.touch(now, fieldIdentity, fieldFlightStatus)
+	}
 }
 
-func processLongMessage(log *slog.Logger, aircraft *model.Aircraft, message model.LongMessage) { //nolint: revive,unparam,lll,whitespace,wsl
+func (s *aircraftState) processLongMessage(log *slog.Logger, message model.LongMessage, now time.Time) { //nolint: revive,unparam,lll,whitespace,wsl
 	switch message.DownlinkFormat() { //nolint: exhaustive
 	case model.DownlinkFormatCommDExtendedLengthMessage:
 		// nothing to do
 	case model.DownlinkFormatLongAirAirSurveillance:
-		// nothing to do
+		surveillance := model.LongAirAirSurveillance{LongMessage: message}
+
+		s.altitude(surveillance.Altitude(), now)
+		s.aircraft.OnGround = surveillance.OnGround()
+
+		crossLink := s.aircraft.ACAS != nil && s.aircraft.ACAS.CrossLink
+
+		s.acas(surveillance.SensitivityLevel(), surveillance.ReplyInformation(), crossLink)
+
+		s.touch(now, fieldACAS)
+
+		if advisory, err := surveillance.ResolutionAdvisory(); err == nil {
+			s.resolutionAdvisory(advisory, now)
+		}
 	case model.DownlinkFormatCommBWithAltitudeReply:
 		commBReplyWithAltitude := model.CommBReplyWithAltitude{LongMessage: message}
 
 		flightStatus := commBReplyWithAltitude.FlightStatus()
 
-		aircraft.Altitude = commBReplyWithAltitude.Altitude()
+		s.altitude(commBReplyWithAltitude.Altitude(), now)
+
+		s.aircraft.FlightStatus = &flightStatus
+
+		s.touch(now, fieldFlightStatus)
 
-		aircraft.FlightStatus = &flightStatus
+		s.processCommB(log, commBReplyWithAltitude.CommB(), now)
 
 	case model.DownlinkFormatCommBWithIdentityReply:
 		commBReplyWithIdentification := model.CommBReplyWithIdentification{LongMessage: message}
 
 		flightStatus := commBReplyWithIdentification.FlightStatus()
 
-		aircraft.Identity = commBReplyWithIdentification.Identity()
+		s.aircraft.Identity = commBReplyWithIdentification.Identity()
+
+		s.aircraft.FlightStatus = &flightStatus
+
+		s.touch(now, fieldIdentity, fieldFlightStatus)
+
+		s.processCommB(log, commBReplyWithIdentification.CommB(), now)
+	}
+}
+
+func (s *aircraftState) processCommB(log *slog.Logger, commB model.CommB, now time.Time) { //nolint: cyclop
+	data, err := commB.Decode()
+	if err != nil {
+		log.Debug("comm-b error", "msg", err)
+
+		return
+	}
+
+	s.touch(now, fieldCommB)
+
+	if data.Callsign != "" {
+		s.aircraft.Identification = data.Callsign
 
-		aircraft.FlightStatus = &flightStatus
+		s.touch(now, fieldIdentification)
 	}
 
-	aircraft.LastUpdate = time.Now()
+	if data.ResolutionAdvisory != nil {
+		s.resolutionAdvisory(*data.ResolutionAdvisory, now)
+	}
+
+	switch {
+	case data.SelectedAltitudeMCP != nil:
+		s.aircraft.SelectedAltitude = data.SelectedAltitudeMCP
+	case data.SelectedAltitudeFMS != nil:
+		s.aircraft.SelectedAltitude = data.SelectedAltitudeFMS
+	}
+
+	if data.BaroSetting != nil {
+		s.aircraft.BaroSetting = data.BaroSetting
+	}
+
+	if data.RollAngle != nil {
+		s.aircraft.RollAngle = data.RollAngle
+	}
+
+	if data.TrackRate != nil {
+		s.aircraft.TrackRate = data.TrackRate
+	}
+
+	if data.Mach != nil {
+		s.aircraft.Mach = data.Mach
+	}
+
+	// The registers are only inferred: a recent ADS-B velocity is more reliable.
+	if now.Sub(s.updatedAt[fieldADSBVelocity]) > adsbVelocityPrecedence && s.commBVelocity(data) {
+		s.touch(now, fieldVelocity)
+	}
 }
 
-func processExtendedSquitter(log *slog.Logger, aircraft *model.Aircraft, squitter extendedSquitter) { //nolint: gocognit,cyclop,lll
-	if len(squitter.Identification) > 0 {
-		identification := squitter.Identification[len(squitter.Identification)-1]
-		aircraft.Category = identification.CategoryString()
-		aircraft.Identification = identification.String()
+// commBVelocity applies the velocity of the Comm-B registers; false when they have none.
+func (s *aircraftState) commBVelocity(data model.CommBData) bool { //nolint: cyclop
+	found := false
+
+	if data.TrueTrack != nil {
+		s.aircraft.Track = data.TrueTrack
+		found = true
+	}
+
+	if data.GroundSpeed != nil {
+		s.aircraft.GroundSpeed = data.GroundSpeed
+		found = true
+	}
+
+	if data.TrueAirSpeed != nil {
+		s.aircraft.TAS = data.TrueAirSpeed
+		found = true
+	}
+
+	if data.MagneticHeading != nil {
+		s.aircraft.MagneticHeading = data.MagneticHeading
+		found = true
+	}
+
+	if data.IndicatedAirSpeed != nil {
+		s.aircraft.IAS = data.IndicatedAirSpeed
+		found = true
+	}
+
+	switch {
+	case data.BaroVerticalRate != nil:
+		s.aircraft.VerticalRate = *data.BaroVerticalRate
+		s.aircraft.BaroVerticalRate = true
+		found = true
+	case data.InertialVerticalRate != nil:
+		s.aircraft.VerticalRate = *data.InertialVerticalRate
+		s.aircraft.BaroVerticalRate = false
+		found = true
 	}
 
-	if !squitter.lastPositionIsAirborne && len(squitter.SurfacePosition) > 0 {
-		aircraft.Altitude = squitter.SurfacePosition[len(squitter.SurfacePosition)-1].Altitude()
+	return found
+}
+
+func (s *aircraftState) processExtendedSquitter( //nolint: cyclop,funlen
+	log *slog.Logger,
+	squitter model.ExtendedSquitter,
+	positions *positionDecoder,
+	now time.Time,
+) {
+	// the source is known even when the message is not decoded (coarse TIS-B, unsupported type codes).
+	s.aircraft.Source = squitter.Source()
+
+	decoded, err := squitter.Decode()
+	if err != nil {
+		log.Debug("extended squitter error", "msg", err)
+
+		return
 	}
 
-	if !squitter.lastPositionIsAirborne && len(squitter.SurfacePosition) > 1 {
-		lastPosition, otherPosition := frames(squitter.SurfacePosition)
+	switch message := decoded.(type) {
+	case model.Identification:
+		s.aircraft.Category = message.CategoryString()
+		s.aircraft.Identification = message.String()
+
+		s.touch(now, fieldIdentification)
+
+	case model.SurfacePosition:
+		s.aircraft.OnGround = true
+
+		speed := message.Speed()
+		if speed >= 0 {
+			s.aircraft.GroundSpeed = &speed
+		}
 
-		if otherPosition != nil {
-			position, err := lastPosition.DecodePosition(*otherPosition)
-			if err != nil {
-				log.Debug("surface position error")
-			} else {
-				aircraft.Position = position
-			}
+		if message.GroundTrackValid() {
+			track := message.GroundTrack()
+			s.aircraft.Track = &track
 		}
+
+		if speed >= 0 || message.GroundTrackValid() {
+			s.touch(now, fieldVelocity, fieldADSBVelocity)
+		}
+
+		if position := decodeFrame(positions, log, s.aircraft.Addr, message, now, s.lastFix(), true); position != nil {
+			s.aircraft.Position = position
+
+			s.touch(now, fieldPosition)
+		}
+
+	case model.AirbornePosition:
+		s.aircraft.OnGround = false
+		s.altitude(message.Altitude(), now)
+
+		if position := decodeFrame(positions, log, s.aircraft.Addr, message, now, s.lastFix(), false); position != nil {
+			s.aircraft.Position = position
+
+			s.touch(now, fieldPosition)
+		}
+
+	case model.AirborneVelocity:
+		s.processAirborneVelocity(message, now)
+
+	case model.OperationStatus:
+		status := message.Status()
+
+		s.aircraft.CurrentOperation = status.String()
+		s.aircraft.ADSBVersion = status.Version
+		s.aircraft.OperationalStatus = &status
+
+		s.touch(now, fieldOperationalStatus)
+
+	case model.TargetStateAndStatus:
+		state, err := message.State()
+		if err != nil {
+			log.Debug("target state error", "msg", err)
+
+			return
+		}
+
+		s.aircraft.TargetState = &state
+
+		s.touch(now, fieldTargetState)
+
+	case model.AircraftStatus:
+		s.processAircraftStatus(log, message, now)
 	}
+}
 
-	if squitter.lastPositionIsAirborne && len(squitter.AirbornePosition) > 0 {
-		aircraft.Altitude = squitter.AirbornePosition[len(squitter.AirbornePosition)-1].Altitude()
+func (s *aircraftState) processAirborneVelocity(message model.AirborneVelocity, now time.Time) {
+	if speed, track, ok := message.GroundVelocity(); ok {
+		s.aircraft.GroundSpeed = &speed
+		s.aircraft.Track = &track
 	}
 
-	if squitter.lastPositionIsAirborne && len(squitter.AirbornePosition) > 1 {
-		lastPosition, otherPosition := frames(squitter.AirbornePosition)
+	if speed, ok := message.AirSpeed(); ok {
+		s.aircraft.AirSpeed = &speed
+		s.aircraft.TrueAirSpeed = message.IsTrueAirSpeed()
 
-		if otherPosition != nil {
-			position, err := lastPosition.DecodePosition(*otherPosition)
-			if err != nil {
-				log.Debug("airborne position error")
-			} else {
-				aircraft.Position = position
-			}
+		if message.IsTrueAirSpeed() {
+			s.aircraft.TAS = &speed
+		} else {
+			s.aircraft.IAS = &speed
 		}
 	}
 
-	if len(squitter.AirborneVelocity) > 0 { //nolint: nestif
-		lastVelocity := squitter.AirborneVelocity[len(squitter.AirborneVelocity)-1]
+	if heading, ok := message.MagneticHeading(); ok {
+		s.aircraft.MagneticHeading = &heading
+	}
 
-		speed, heading := lastVelocity.Speed()
+	if verticalRate, ok := message.VerticalRate(); ok {
+		s.aircraft.BaroVerticalRate = message.IsBaroVerticalRate()
+		s.aircraft.VerticalRate = verticalRate
+	}
 
-		if speed >= 0 {
-			if heading > 0 {
-				aircraft.Track = &heading
-			}
-
-			if lastVelocity.IsGroundSpeed() {
-				aircraft.GroundSpeed = &speed
-			} else {
-				aircraft.AirSpeed = &speed
-			}
-
-			aircraft.BaroVerticalRate = lastVelocity.IsBaroVerticalRate()
-			aircraft.TrueAirSpeed = lastVelocity.IsTrueAirSpeed()
-			aircraft.VerticalRate = lastVelocity.VerticalRate()
-			aircraft.DeltaBarometric = lastVelocity.DeltaBarometric()
+	s.aircraft.DeltaBarometric = message.DeltaBarometric()
+
+	// The GNSS altitude is derived from the last barometric altitude; only the available altitudes are dated.
+	if message.DeltaBarometricAvailable() && !s.updatedAt[fieldAltitude].IsZero() && !s.aircraft.OnGround {
+		geometricAltitude := s.aircraft.Altitude + float64(message.DeltaBarometric())
+		s.aircraft.GeometricAltitude = &geometricAltitude
+	}
+
+	s.touch(now, fieldVelocity, fieldADSBVelocity)
+}
+
+func (s *aircraftState) processAircraftStatus(log *slog.Logger, status model.AircraftStatus, now time.Time) {
+	switch status.SubTypeCode() { //nolint: exhaustive
+	case model.AircraftStatusEmergency:
+		s.aircraft.EmergencyState = status.Emergency()
+
+		s.touch(now, fieldEmergency)
+
+		if squawk := status.Squawk(); squawk != 0 {
+			s.aircraft.Identity = squawk
+
+			s.touch(now, fieldIdentity)
+		}
+
+	case model.AircraftStatusResolutionAdvisory:
+		advisory, err := status.ResolutionAdvisory()
+		if err != nil {
+			log.Debug("resolution advisory error", "msg", err)
+
+			return
 		}
+
+		s.resolutionAdvisory(advisory, now)
 	}
+}
+
+// resolutionAdvisory records the advisory, and raises an event when an advisory starts, changes or ends.
+func (s *aircraftState) resolutionAdvisory(advisory model.ResolutionAdvisory, now time.Time) {
+	previous := s.aircraft.ResolutionAdvisory
+
+	s.aircraft.ResolutionAdvisory = &advisory
+
+	s.touch(now, fieldResolutionAdvisory)
 
-	if len(squitter.OperationStatus) > 0 {
-		aircraft.CurrentOperation = squitter.OperationStatus[len(squitter.OperationStatus)-1].String()
+	if previous != nil && previous.Equal(advisory) {
+		return
 	}
 
-	aircraft.LastUpdate = time.Now()
+	if !advisory.Active() && (previous == nil || !previous.Active()) {
+		return
+	}
+
+	s.events = append(s.events, model.Event{
+		Type:               model.EventResolutionAdvisory,
+		Addr:               s.aircraft.Addr,
+		Identification:     s.aircraft.Identification,
+		Date:               now,
+		ResolutionAdvisory: &advisory,
+	})
 }
 
-func frames[T model.OddEven](dataSet []T) (T, *T) { //nolint: ireturn
-	first := dataSet[len(dataSet)-1]
+// acas updates the ACAS status; the capability and the maximum airspeed share the same field.
+func (s *aircraftState) acas(sensitivityLevel uint8, replyInformation model.ReplyInformation, crossLink bool) {
+	acas := model.ACAS{}
+
+	if s.aircraft.ACAS != nil {
+		acas = *s.aircraft.ACAS
+	}
 
-	return first, findFrame[T](dataSet, !first.OddFrame())
+	acas.SensitivityLevel = sensitivityLevel
+	acas.CrossLink = crossLink
+
+	if replyInformation.IsAirspeed() {
+		acas.MaxAirspeed = &replyInformation
+	} else {
+		acas.Capability = &replyInformation
+	}
+
+	s.aircraft.ACAS = &acas
 }
 
-func findFrame[T model.OddEven](dataSet []T, odd bool) *T {
-	for idx := len(dataSet) - 2; idx >= 0; idx-- { //nolint: gomnd
-		if dataSet[idx].OddFrame() == odd {
-			return &dataSet[idx]
-		}
+// altitude records the barometric altitude; a missing one keeps the last altitude.
+func (s *aircraftState) altitude(altitude float64, now time.Time) {
+	if altitude == model.AltitudeNotAvailable {
+		return
 	}
 
-	return nil
+	s.aircraft.Altitude = altitude
+
+	s.touch(now, fieldAltitude)
+}
+
+// lastFix is the last decoded position, with its date.
+func (s *aircraftState) lastFix() positionFix {
+	return positionFix{
+		position: s.aircraft.Position,
+		date:     s.updatedAt[fieldPosition],
+	}
 }
@@ -7,15 +7,23 @@ import (
 	"time"
 
 	"github.com/landru29/adsb1090/internal/aircraftdb"
+	"github.com/landru29/adsb1090/internal/binary"
 	"github.com/landru29/adsb1090/internal/database"
 	"github.com/landru29/adsb1090/internal/errors"
 	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/stats"
 	"github.com/landru29/adsb1090/internal/transport"
 )
 
 const (
 	// ErrReferenceAircraftNotFound is when the reference aircraft was not found in the world database.
 	ErrReferenceAircraftNotFound errors.Error = "aircraft not found in the world database"
+
+	// ErrInvalidAddress is when the aircraft address cannot be used.
+	ErrInvalidAddress errors.Error = "invalid aircraft address"
+
+	// ErrUnconfirmedAddress is when the address of an address/parity frame was not confirmed recently.
+	ErrUnconfirmedAddress errors.Error = "unconfirmed aircraft address"
 )
 
 // Configurator is the Process configurator.
@@ -23,29 +31,47 @@ type Configurator func(*Process)
 
 // Process is the data processor.
 type Process struct {
-	ExtendedSquitters     *database.ChainedStorage[model.ICAOAddr, model.QualifiedMessage]
+	aircraft              *database.ElementStorage[model.ICAOAddr, *aircraftState]
 	log                   *slog.Logger
 	dbLifeTime            time.Duration
 	transporters          []transport.Transporter
+	frameTransporters     []transport.FrameTransporter
+	eventTransporters     []transport.EventTransporter
 	aircraftWorldDatabase aircraftdb.Database
+	errorCorrector        *binary.ErrorCorrector
+	strictReference       bool
+	receiver              *model.Receiver
+	positions             *positionDecoder
+	addresses             *addressFilter
+	addressLifetime       time.Duration
+	stats                 *stats.Stats
+	startedAt             time.Time
 }
 
 // New creates a data processor.
 func New(ctx context.Context, log *slog.Logger, opts ...Configurator) *Process {
 	process := &Process{
-		log:          log,
-		transporters: []transport.Transporter{},
+		log:               log,
+		transporters:      []transport.Transporter{},
+		frameTransporters: []transport.FrameTransporter{},
+		eventTransporters: []transport.EventTransporter{},
+		addressLifetime:   defaultAddressLifetime,
+		startedAt:         time.Now(),
 	}
 
 	for _, opt := range opts {
 		opt(process)
 	}
 
-	process.ExtendedSquitters = database.NewChainedStorage[model.ICAOAddr, model.QualifiedMessage](
+	process.aircraft = database.NewElementStorage[model.ICAOAddr, *aircraftState](
 		ctx,
-		database.ChainedWithLifetime[model.ICAOAddr, model.QualifiedMessage](process.dbLifeTime),
+		database.ElementWithLifetime[model.ICAOAddr, *aircraftState](process.dbLifeTime),
 	)
 
+	process.positions = newPositionDecoder(ctx, process.receiver, process.dbLifeTime, process.stats)
+
+	process.addresses = newAddressFilter(ctx, process.addressLifetime)
+
 	return process
 }
 
@@ -63,6 +89,41 @@ func WithDatabaseLifetime(dbLifeTime time.Duration) Configurator {
 	}
 }
 
+// WithAddressLifetime sets how long a confirmed address validates the address/parity frames.
+func WithAddressLifetime(lifetime time.Duration) Configurator {
+	return func(process *Process) {
+		process.addressLifetime = lifetime
+	}
+}
+
+// WithErrorCorrector sets the CRC error corrector.
+func WithErrorCorrector(corrector *binary.ErrorCorrector) Configurator {
+	return func(process *Process) {
+		process.errorCorrector = corrector
+	}
+}
+
+// WithStrictReference only keeps aircraft found in the world database.
+func WithStrictReference() Configurator {
+	return func(process *Process) {
+		process.strictReference = true
+	}
+}
+
+// WithReceiver sets the receiver location, to decode the positions from a single frame.
+func WithReceiver(receiver model.Receiver) Configurator {
+	return func(process *Process) {
+		process.receiver = &receiver
+	}
+}
+
+// WithStats records the decoder activity.
+func WithStats(statistics *stats.Stats) Configurator {
+	return func(process *Process) {
+		process.stats = statistics
+	}
+}
+
 // WithTransporter add a new transporter.
 func WithTransporter(transporter transport.Transporter) Configurator {
 	return func(process *Process) {
@@ -70,49 +131,180 @@ func WithTransporter(transporter transport.Transporter) Configurator {
 	}
 }
 
+// WithFrameTransporter add a new raw frame transporter.
+func WithFrameTransporter(transporter transport.FrameTransporter) Configurator {
+	return func(process *Process) {
+		process.frameTransporters = append(process.frameTransporters, transporter)
+	}
+}
+
+// WithEventTransporter add a new aircraft event transporter.
+func WithEventTransporter(transporter transport.EventTransporter) Configurator {
+	return func(process *Process) {
+		process.eventTransporters = append(process.eventTransporters, transporter)
+	}
+}
+
 // Process implements source.Processor the interface.
-func (p Process) Process(data []byte) error {
-	modes := model.ModeS(data)
+func (p Process) Process(frame model.Frame) error {
+	modes := frame.ModeS
 
 	log := p.log.With("message", modes.String())
 
-	squitter, err := modes.QualifiedMessage()
+	p.stats.Frame()
+
+	fixed, err := modes.Fix(p.errorCorrector)
 	if err != nil {
+		p.stats.CRCFailure()
+
 		return err
 	}
 
-	if _, isExtended := squitter.(model.LongMessage); isExtended {
-		if err := modes.CheckSum(); err != nil {
-			return err
-		}
+	if fixed > 0 {
+		log.Debug("message repaired", "bits", fixed, "repaired", modes.String())
+	}
+
+	frame.CorrectedBits += fixed
+
+	if frame.CorrectedBits > 0 {
+		p.stats.Corrected()
+	}
+
+	if frame.ReceivedAt.IsZero() {
+		frame.ReceivedAt = time.Now()
+	}
+
+	squitter, err := modes.QualifiedMessage()
+	if err != nil {
+		return err
 	}
 
 	log.Info("processing message")
 
 	icaoAddress := squitter.AircraftAddress()
+	if icaoAddress == 0 {
+		return ErrInvalidAddress
+	}
+
+	switch {
+	case modes.AddressParity(),
+		// the military extended squitters have no parity to check: any noise would give an aircraft.
+		modes.DownlinkFormat() == model.DownlinkFormatMilitaryExtendedSquitter:
+		if !p.addresses.accept(icaoAddress, frame.ReceivedAt) {
+			p.stats.AddressRejection()
+
+			return ErrUnconfirmedAddress
+		}
+	case confirming(modes, frame.CorrectedBits) && icaoAddress.AddressType() == model.AddressTypeICAO:
+		p.addresses.confirm(icaoAddress, frame.ReceivedAt)
+	}
+
+	p.transportFrame(log, frame)
+
+	var reference *aircraftdb.Entry
 
 	aircraftReference, found := p.aircraftWorldDatabase[icaoAddress]
-	if !found {
+
+	switch {
+	case found:
+		reference = &aircraftReference
+
+		log = log.
+			With("registration", aircraftReference.Registration).
+			With("model", aircraftReference.Model).
+			With("operator", aircraftReference.Operator).
+			With("manufacturer", aircraftReference.ManufacturerName)
+
+		log.Info("aircraft found")
+	case p.strictReference:
 		return ErrReferenceAircraftNotFound
+	default:
+		log.Info("aircraft not found in the world database", "addr", icaoAddress)
 	}
 
-	log = log.
-		With("registration", aircraftReference.Registration).
-		With("model", aircraftReference.Model).
-		With("operator", aircraftReference.Operator).
-		With("manufacturer", aircraftReference.ManufacturerName)
-
-	log.Info("aircraft found")
+	p.stats.Message(squitter)
 
-	p.ExtendedSquitters.Add(icaoAddress, squitter)
+	state := p.state(icaoAddress, reference)
 
-	aircraft := buildAircraft(log, p.ExtendedSquitters.Elements(icaoAddress), aircraftReference)
+	aircraft, events := state.update(log, squitter, p.positions, frame)
 
 	for _, transporter := range p.transporters {
-		if err := transporter.Transport(aircraft); err != nil {
-			log.Error("transport", "msg", err)
+		err := transporter.Transport(&aircraft)
+		if err != nil {
+			log.Error("transport", "name", transporter.String(), "msg", err)
+		}
+
+		p.stats.Transported(transporter.String(), err)
+	}
+
+	for _, event := range events {
+		log.Warn("aircraft event", "event", event.String())
+
+		for _, transporter := range p.eventTransporters {
+			if err := transporter.TransportEvent(event); err != nil {
+				log.Error("event transport", "name", transporter.String(), "msg", err)
+			}
 		}
 	}
 
 	return nil
 }
+
+// state is the current state of an aircraft; its lifetime is extended on each message.
+func (p Process) state(icaoAddress model.ICAOAddr, reference *aircraftdb.Entry) *aircraftState {
+	state := newAircraftState(icaoAddress, reference)
+
+	if current := p.aircraft.Element(icaoAddress); current != nil {
+		state = *current
+	}
+
+	p.aircraft.Add(icaoAddress, state)
+
+	return state
+}
+
+// Positions is the history of the position frames, by aircraft.
+func (p Process) Positions() *database.ChainedStorage[model.ICAOAddr, PositionFrame] {
+	return p.positions.frames
+}
+
+// Storages are the decoder storages, by name.
+func (p Process) Storages() map[string]database.Sizer {
+	return map[string]database.Sizer{
+		"aircraft":  p.aircraft,
+		"positions": p.positions.frames,
+		"addresses": p.addresses.confirmed,
+	}
+}
+
+// AddressRejections is the count of the address/parity frames rejected, with an unconfirmed address.
+func (p Process) AddressRejections() uint64 {
+	return p.addresses.rejections.Load()
+}
+
+// PositionRejections is the count of the rejected positions.
+func (p Process) PositionRejections() *PositionRejections {
+	return p.positions.rejections
+}
+
+// transportFrame sends the validated frame to the raw frame transporters.
+// Without a reception counter, the frame is timestamped with the 12 MHz counter elapsed since the decoder started.
+func (p Process) transportFrame(log *slog.Logger, frame model.Frame) {
+	if len(p.frameTransporters) == 0 {
+		return
+	}
+
+	if frame.Timestamp == 0 {
+		frame.Timestamp = uint64(frame.ReceivedAt.Sub(p.startedAt).Nanoseconds()) * 12 / 1000 //nolint: gomnd
+	}
+
+	if frame.Signal == 0 {
+		frame.Signal = model.SignalFromRSSI(frame.RSSI)
+	}
+
+	for _, transporter := range p.frameTransporters {
+		if err := transporter.TransportFrame(frame); err != nil {
+			log.Error("frame transport", "name", transporter.String(), "msg", err)
+		}
+	}
+}
@@ -0,0 +1,761 @@
+package decoder_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/aircraftdb"
+	"github.com/landru29/adsb1090/internal/binary"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/processor/decoder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transporter struct {
+	aircraft []model.Aircraft
+	events   []model.Event
+}
+
+func (t *transporter) Transport(ac *model.Aircraft) error {
+	t.aircraft = append(t.aircraft, *ac)
+
+	return nil
+}
+
+func (t *transporter) TransportEvent(event model.Event) error {
+	t.events = append(t.events, event)
+
+	return nil
+}
+
+func (t *transporter) String() string {
+	return "test"
+}
+
+type frameTransporter struct {
+	frames []model.Frame
+}
+
+func (t *frameTransporter) TransportFrame(frame model.Frame) error {
+	t.frames = append(t.frames, frame)
+
+	return nil
+}
+
+func (t *frameTransporter) String() string {
+	return "test"
+}
+
+func frame(t *testing.T, str string) model.Frame {
+	t.Helper()
+
+	dataByte, err := hex.DecodeString(str)
+	require.NoError(t, err)
+
+	return model.NewFrame(dataByte)
+}
+
+func TestReferenceDatabase(t *testing.T) {
+	t.Parallel()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	worldDatabase := aircraftdb.Database{
+		0x4840D6: aircraftdb.Entry{Addr: 0x4840D6, Registration: "PH-KZD"},
+	}
+
+	t.Run("aircraft in the world database", func(t *testing.T) {
+		t.Parallel()
+
+		output := &transporter{}
+
+		process := decoder.New(
+			context.Background(),
+			log,
+			decoder.WithAircraftWorldDatabase(worldDatabase),
+			decoder.WithTransporter(output),
+		)
+
+		require.NoError(t, process.Process(frame(t, "8D4840D6202CC371C32CE0576098")))
+
+		require.Len(t, output.aircraft, 1)
+		assert.True(t, output.aircraft[0].ReferenceFound)
+		assert.Equal(t, "PH-KZD", output.aircraft[0].Registration)
+		assert.Equal(t, model.ICAOAddr(0x4840D6), output.aircraft[0].Addr)
+	})
+
+	t.Run("aircraft missing from the world database", func(t *testing.T) {
+		t.Parallel()
+
+		output := &transporter{}
+
+		process := decoder.New(
+			context.Background(),
+			log,
+			decoder.WithAircraftWorldDatabase(worldDatabase),
+			decoder.WithTransporter(output),
+		)
+
+		require.NoError(t, process.Process(frame(t, "8D40621D58C382D690C8AC2863A7")))
+
+		require.Len(t, output.aircraft, 1)
+		assert.False(t, output.aircraft[0].ReferenceFound)
+		assert.Empty(t, output.aircraft[0].Registration)
+		assert.Equal(t, model.ICAOAddr(0x40621D), output.aircraft[0].Addr)
+	})
+
+	t.Run("strict mode", func(t *testing.T) {
+		t.Parallel()
+
+		output := &transporter{}
+
+		process := decoder.New(
+			context.Background(),
+			log,
+			decoder.WithAircraftWorldDatabase(worldDatabase),
+			decoder.WithTransporter(output),
+			decoder.WithStrictReference(),
+		)
+
+		require.ErrorIs(
+			t,
+			process.Process(frame(t, "8D40621D58C382D690C8AC2863A7")),
+			decoder.ErrReferenceAircraftNotFound,
+		)
+
+		assert.Empty(t, output.aircraft)
+	})
+}
+
+func TestFrameTransporter(t *testing.T) {
+	t.Parallel()
+
+	output := &frameTransporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithFrameTransporter(output),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8D4840D6202CC371C32CE0576098")))
+	require.ErrorIs(t, process.Process(frame(t, "8D4840D6202CC371C32CE0576099")), model.ErrWrongCRC)
+	require.NoError(t, process.Process(frame(t, "8D40621D58C382D690C8AC2863A7")))
+
+	require.Len(t, output.frames, 2)
+	assert.Equal(t, "8D4840D6202CC371C32CE0576098", output.frames[0].ModeS.String())
+	assert.Equal(t, "8D40621D58C382D690C8AC2863A7", output.frames[1].ModeS.String())
+	assert.LessOrEqual(t, output.frames[0].Timestamp, output.frames[1].Timestamp)
+}
+
+func TestCommB(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+	)
+
+	require.NoError(t, process.Process(frame(t, "5D4841630F9218")))
+	require.NoError(t, process.Process(frame(t, "A000083E202CC371C31DE0AA1CCF")))
+
+	require.Len(t, output.aircraft, 2)
+	assert.Equal(t, "KLM1017 ", output.aircraft[1].Identification)
+}
+
+func TestCommBVelocity(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Hour),
+	)
+
+	now := time.Now()
+
+	velocity := frame(t, "8D485020994409940838175B284F")
+	velocity.ReceivedAt = now
+
+	require.NoError(t, process.Process(velocity))
+	require.Len(t, output.aircraft, 1)
+	require.NotNil(t, output.aircraft[0].GroundSpeed)
+
+	adsbSpeed := *output.aircraft[0].GroundSpeed
+
+	// Track and turn report, shortly after the ADS-B velocity.
+	commB := frame(t, "A000139381951536E024D4B8EB47")
+	commB.ReceivedAt = now.Add(5 * time.Second)
+
+	require.NoError(t, process.Process(commB))
+	require.Len(t, output.aircraft, 2)
+
+	aircraft := output.aircraft[1]
+
+	require.NotNil(t, aircraft.GroundSpeed)
+	assert.InDelta(t, adsbSpeed, *aircraft.GroundSpeed, 0.01)
+	require.NotNil(t, aircraft.RollAngle)
+	require.NotNil(t, aircraft.Seen.Velocity)
+	assert.Equal(t, uint64(1), aircraft.Seen.Velocity.Messages)
+
+	// Without any recent ADS-B velocity.
+	commB = frame(t, "A000139381951536E024D4B8EB47")
+	commB.ReceivedAt = now.Add(time.Minute)
+
+	require.NoError(t, process.Process(commB))
+	require.Len(t, output.aircraft, 3)
+
+	aircraft = output.aircraft[2]
+
+	require.NotNil(t, aircraft.GroundSpeed)
+	assert.InDelta(t, 438.0, *aircraft.GroundSpeed, 0.01)
+	require.NotNil(t, aircraft.Seen.Velocity)
+	assert.Equal(t, uint64(2), aircraft.Seen.Velocity.Messages)
+	assert.Equal(t, commB.ReceivedAt, aircraft.Seen.Velocity.At)
+}
+
+func TestReceiver(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithReceiver(model.Receiver{
+			Position: model.Position{Latitude: 51.5, Longitude: 4.5},
+			MaxRange: 300000,
+		}),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8D40621D58C382D690C8AC2863A7")))
+
+	require.Len(t, output.aircraft, 1)
+	require.NotNil(t, output.aircraft[0].Position)
+	assert.InDelta(t, 52.2572, output.aircraft[0].Position.Latitude, 0.0001)
+	assert.InDelta(t, 3.9194, output.aircraft[0].Position.Longitude, 0.0001)
+}
+
+func TestImpossibleSpeed(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+		decoder.WithReceiver(model.Receiver{
+			Position: model.Position{Latitude: 51.5, Longitude: 4.5},
+			MaxRange: 300000,
+		}),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8D40621D58C382D690C8AC2863A7")))
+	// Same frame with a latitude 1.5° further north, received a few microseconds later.
+	require.NoError(t, process.Process(frame(t, "8D40621D58C383D690C8AC2B6969")))
+
+	require.Len(t, output.aircraft, 2)
+	require.NotNil(t, output.aircraft[1].Position)
+	assert.InDelta(t, 52.2572, output.aircraft[1].Position.Latitude, 0.0001)
+	assert.InDelta(t, 3.9194, output.aircraft[1].Position.Longitude, 0.0001)
+
+	assert.Equal(t, uint64(1), process.PositionRejections().ImpossibleSpeed.Load())
+	assert.Equal(t, uint64(1), process.PositionRejections().Total())
+}
+
+func TestPairTooOld(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Hour),
+	)
+
+	now := time.Now()
+
+	even := frame(t, "8D40621D58C382D690C8AC2863A7")
+	even.ReceivedAt = now.Add(-5 * time.Minute)
+
+	odd := frame(t, "8D40621D58C386435CC412692AD6")
+	odd.ReceivedAt = now
+
+	require.NoError(t, process.Process(even))
+	require.NoError(t, process.Process(odd))
+
+	require.Len(t, output.aircraft, 2)
+	assert.Nil(t, output.aircraft[1].Position)
+
+	assert.Equal(t, uint64(1), process.PositionRejections().PairTooOld.Load())
+	assert.Equal(t, uint64(1), process.PositionRejections().Total())
+}
+
+func TestIncrementalState(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8D40621D58C382D690C8AC2863A7")))
+	require.NoError(t, process.Process(frame(t, "8D40621D58C386435CC412692AD6")))
+
+	require.Len(t, output.aircraft, 2)
+	assert.Nil(t, output.aircraft[0].Position)
+	require.NotNil(t, output.aircraft[1].Position)
+	assert.InDelta(t, 52.2658, output.aircraft[1].Position.Latitude, 0.0001)
+	assert.InDelta(t, 3.9389, output.aircraft[1].Position.Longitude, 0.0001)
+	assert.InDelta(t, 38000.0, output.aircraft[1].Altitude, 0.1)
+
+	assert.Equal(t, uint64(2), output.aircraft[1].Messages)
+	require.NotNil(t, output.aircraft[1].Seen.Altitude)
+	assert.Equal(t, uint64(2), output.aircraft[1].Seen.Altitude.Messages)
+	require.NotNil(t, output.aircraft[1].Seen.Position)
+	assert.Equal(t, uint64(1), output.aircraft[1].Seen.Position.Messages)
+	assert.Nil(t, output.aircraft[1].Seen.Velocity)
+
+	assert.Len(t, process.Positions().Elements(0x40621D), 2)
+}
+
+func TestSurfacePosition(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+		decoder.WithReceiver(model.Receiver{Position: model.Position{Latitude: 51.99, Longitude: 4.37}}),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8C4841753AAB238733C8CD4020B1")))
+	require.NoError(t, process.Process(frame(t, "8C4841753A8A35323FAEBDAC702D")))
+
+	require.Len(t, output.aircraft, 2)
+
+	aircraft := output.aircraft[1]
+
+	assert.True(t, aircraft.OnGround)
+	assert.True(t, aircraft.Ground())
+	assert.Zero(t, aircraft.Altitude)
+	assert.Nil(t, aircraft.Seen.Altitude)
+
+	require.NotNil(t, aircraft.GroundSpeed)
+	assert.InDelta(t, 16.0, *aircraft.GroundSpeed, 0.001)
+	require.NotNil(t, aircraft.Track)
+	assert.InDelta(t, 98.4375, *aircraft.Track, 0.001)
+	require.NotNil(t, aircraft.Seen.Velocity)
+	assert.Equal(t, uint64(2), aircraft.Seen.Velocity.Messages)
+
+	require.NotNil(t, aircraft.Position)
+	assert.InDelta(t, 52.32, aircraft.Position.Latitude, 0.01)
+	assert.InDelta(t, 4.73, aircraft.Position.Longitude, 0.01)
+}
+
+func TestSurfacePositionKeepsAltitude(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+		decoder.WithReceiver(model.Receiver{Position: model.Position{Latitude: 51.99, Longitude: 4.37}}),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8C4841753AAB238733C8CD4020B1")))
+	require.NoError(t, process.Process(frame(t, "2000183859C22E")))
+	require.NoError(t, process.Process(frame(t, "8C4841753A8A35323FAEBDAC702D")))
+
+	require.Len(t, output.aircraft, 3)
+
+	// The surface position carries no altitude: the last one is kept, the ground is told by OnGround.
+	aircraft := output.aircraft[2]
+
+	assert.True(t, aircraft.OnGround)
+	assert.InDelta(t, 38000.0, aircraft.Altitude, 0.1)
+	require.NotNil(t, aircraft.Seen.Altitude)
+	assert.Equal(t, uint64(1), aircraft.Seen.Altitude.Messages)
+}
+
+func TestSurfacePositionWithoutReference(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8C4841753AAB238733C8CD4020B1")))
+	require.NoError(t, process.Process(frame(t, "8C4841753A8A35323FAEBDAC702D")))
+
+	require.Len(t, output.aircraft, 2)
+
+	// Without the receiver or a previous fix, the quadrant of the surface position is unknown.
+	assert.Nil(t, output.aircraft[1].Position)
+	require.NotNil(t, output.aircraft[1].GroundSpeed)
+	assert.Equal(t, uint64(2), process.PositionRejections().NoReference.Load())
+}
+
+func TestSurfacePositionPair(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Hour),
+	)
+
+	now := time.Now()
+
+	// Airborne fix before landing, too old to decode a single surface frame.
+	for idx, str := range []string{"8D484175580B02DDDEF3B61FB683", "8D484175580B06491AECF1134E84"} {
+		airborne := frame(t, str)
+		airborne.ReceivedAt = now.Add(-20*time.Minute + time.Duration(idx)*time.Second)
+
+		require.NoError(t, process.Process(airborne))
+	}
+
+	require.Len(t, output.aircraft, 2)
+	require.NotNil(t, output.aircraft[1].Position)
+
+	even := frame(t, "8C4841753AAB238733C8CD4020B1")
+	even.ReceivedAt = now.Add(-40 * time.Second)
+
+	require.NoError(t, process.Process(even))
+
+	odd := frame(t, "8C4841753A8A35323FAEBDAC702D")
+	odd.ReceivedAt = now
+
+	require.NoError(t, process.Process(odd))
+
+	require.Len(t, output.aircraft, 4)
+
+	// The pair is decoded in the quadrant of the last fix.
+	aircraft := output.aircraft[3]
+
+	require.NotNil(t, aircraft.Position)
+	assert.InDelta(t, 52.32061, aircraft.Position.Latitude, 0.00001)
+	assert.InDelta(t, 4.73473, aircraft.Position.Longitude, 0.00001)
+
+	// The surface pair is too far apart.
+	late := frame(t, "8C4841753AAB238733C8CD4020B1")
+	late.ReceivedAt = now.Add(time.Minute)
+
+	require.NoError(t, process.Process(late))
+
+	assert.Equal(t, uint64(1), process.PositionRejections().PairTooOld.Load())
+}
+
+func TestAirSpeed(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8DA05F219B06B6AF189400CBC33F")))
+
+	require.Len(t, output.aircraft, 1)
+
+	aircraft := output.aircraft[0]
+
+	assert.Nil(t, aircraft.Track)
+	assert.Nil(t, aircraft.GroundSpeed)
+	assert.Nil(t, aircraft.IAS)
+	assert.Nil(t, aircraft.GeometricAltitude)
+	require.NotNil(t, aircraft.TAS)
+	assert.InDelta(t, 375, *aircraft.TAS, 0.01)
+	require.NotNil(t, aircraft.MagneticHeading)
+	assert.InDelta(t, 243.98, *aircraft.MagneticHeading, 0.01)
+	require.NotNil(t, aircraft.Seen.Velocity)
+}
+
+func TestVerticalRateNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8D485020994409940838175B284F")))
+	// same velocity, without the vertical rate.
+	require.NoError(t, process.Process(frame(t, "8D48502099440994080017F5D846")))
+
+	require.Len(t, output.aircraft, 2)
+
+	aircraft := output.aircraft[1]
+
+	assert.EqualValues(t, -832, aircraft.VerticalRate)
+	assert.False(t, aircraft.BaroVerticalRate)
+	require.NotNil(t, aircraft.GroundSpeed)
+}
+
+func TestAltitudeNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8D485020994409940838175B284F")))
+
+	// Surveillance reply without the altitude.
+	require.NoError(t, process.Process(frame(t, "20000000C8367F")))
+	require.NoError(t, process.Process(frame(t, "8D485020994409940838175B284F")))
+
+	require.Len(t, output.aircraft, 3)
+	assert.Nil(t, output.aircraft[2].GeometricAltitude)
+	assert.Nil(t, output.aircraft[2].Seen.Altitude)
+
+	// Surveillance reply at 38000 feet.
+	require.NoError(t, process.Process(frame(t, "2000183859D37B")))
+	require.NoError(t, process.Process(frame(t, "20000000C8367F")))
+	require.NoError(t, process.Process(frame(t, "8D485020994409940838175B284F")))
+
+	require.Len(t, output.aircraft, 6)
+
+	aircraft := output.aircraft[5]
+
+	assert.InDelta(t, 38000.0, aircraft.Altitude, 0.1)
+	require.NotNil(t, aircraft.Seen.Altitude)
+	assert.Equal(t, uint64(1), aircraft.Seen.Altitude.Messages)
+	require.NotNil(t, aircraft.GeometricAltitude)
+	assert.InDelta(t, 38550.0, *aircraft.GeometricAltitude, 0.1)
+}
+
+func TestAirAirSurveillance(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithEventTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	require.NoError(t, process.Process(frame(t, "5D40621D4F94D0")))
+	require.NoError(t, process.Process(frame(t, "02E18C837668DA")))
+	require.NoError(t, process.Process(frame(t, "80E18C8330840006AF37BCA267AB")))
+	require.NoError(t, process.Process(frame(t, "80E18C8330840006AF37BCA267AB")))
+	require.NoError(t, process.Process(frame(t, "80E18C8330000026AF37BC98B6D1")))
+
+	require.Len(t, output.aircraft, 5)
+
+	aircraft := output.aircraft[2]
+
+	assert.Equal(t, model.ICAOAddr(0x40621D), aircraft.Addr)
+	assert.InDelta(t, 38000.0, aircraft.Altitude, 0.1)
+	require.NotNil(t, aircraft.ACAS)
+	assert.Equal(t, uint8(7), aircraft.ACAS.SensitivityLevel)
+	assert.True(t, aircraft.ACAS.CrossLink)
+	require.NotNil(t, aircraft.ACAS.Capability)
+	assert.Equal(t, model.ReplyInformationVertical, *aircraft.ACAS.Capability)
+	require.NotNil(t, aircraft.ResolutionAdvisory)
+	assert.True(t, aircraft.ResolutionAdvisory.Active())
+
+	require.Len(t, output.events, 2)
+	assert.Equal(t, model.EventResolutionAdvisory, output.events[0].Type)
+	assert.Equal(t, model.ICAOAddr(0x40621D), output.events[0].Addr)
+	assert.True(t, output.events[0].ResolutionAdvisory.Active())
+	assert.True(t, output.events[1].ResolutionAdvisory.Terminated)
+}
+
+func TestNonTransponderSquitter(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	require.NoError(t, process.Process(frame(t, "8D4840D6202CC371C32CE0576098")))
+	require.NoError(t, process.Process(frame(t, "914840D6202CC371C32CE0721D15")))
+	require.NoError(t, process.Process(frame(t, "964840D6202CC371C32CE004BF74")))
+	require.NoError(t, process.Process(frame(t, "9340621D80C382D690C8ACB93DF1")))
+
+	require.Len(t, output.aircraft, 4)
+
+	assert.Equal(t, model.SourceADSB, output.aircraft[0].Source)
+	assert.Equal(t, model.AddressTypeICAO, output.aircraft[0].AddressType)
+
+	// The non-ICAO address is another aircraft.
+	assert.Equal(t, "~4840D6", output.aircraft[1].Addr.String())
+	assert.Equal(t, model.AddressTypeNonICAO, output.aircraft[1].AddressType)
+	assert.Equal(t, uint64(1), output.aircraft[1].Messages)
+
+	assert.Equal(t, model.SourceADSR, output.aircraft[2].Source)
+	assert.Equal(t, "KLM1023 ", output.aircraft[2].Identification)
+	assert.Equal(t, uint64(2), output.aircraft[2].Messages)
+
+	// The coarse TIS-B messages are not decoded, but give the source.
+	assert.Equal(t, model.SourceTISB, output.aircraft[3].Source)
+}
+
+func TestNonTransponderSquitterAddress(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	// The management and reserved messages carry no aircraft address.
+	require.ErrorIs(t, process.Process(frame(t, "9440621D58C382D690C8ACCB5EBB")), decoder.ErrInvalidAddress)
+	require.ErrorIs(t, process.Process(frame(t, "9740621D58C382D690C8AC23CD33")), decoder.ErrInvalidAddress)
+	assert.Empty(t, output.aircraft)
+
+	// The coarse TIS-B messages are not decoded, and do not confirm the address.
+	require.NoError(t, process.Process(frame(t, "9340621D00C382D690C8AC8650E0")))
+	require.ErrorIs(t, process.Process(frame(t, "02E18C837668DA")), decoder.ErrUnconfirmedAddress)
+
+	require.Len(t, output.aircraft, 1)
+	assert.Equal(t, model.ICAOAddr(0x40621D), output.aircraft[0].Addr)
+}
+
+func TestAddressFilter(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	require.ErrorIs(t, process.Process(frame(t, "02E18C837668DA")), decoder.ErrUnconfirmedAddress)
+	assert.Equal(t, uint64(1), process.AddressRejections())
+	assert.Empty(t, output.aircraft)
+
+	// The all-call replies to an interrogator are decoded, but do not confirm the address.
+	require.NoError(t, process.Process(frame(t, "5D40621D4F94C5")))
+	require.ErrorIs(t, process.Process(frame(t, "02E18C837668DA")), decoder.ErrUnconfirmedAddress)
+	assert.Equal(t, uint64(2), process.AddressRejections())
+
+	// All-call reply to the interrogator 0.
+	require.NoError(t, process.Process(frame(t, "5D40621D4F94D0")))
+	require.NoError(t, process.Process(frame(t, "02E18C837668DA")))
+	assert.Equal(t, uint64(2), process.AddressRejections())
+
+	require.Len(t, output.aircraft, 3)
+	assert.Equal(t, model.ICAOAddr(0x40621D), output.aircraft[2].Addr)
+	require.NotNil(t, output.aircraft[2].Capability)
+	assert.Equal(t, model.TransponderCapabilityAirborne, *output.aircraft[2].Capability)
+	assert.InDelta(t, 38000.0, output.aircraft[2].Altitude, 0.1)
+
+	// The confirmation expires.
+	late := frame(t, "02E18C837668DA")
+	late.ReceivedAt = time.Now().Add(2 * time.Minute)
+
+	require.ErrorIs(t, process.Process(late), decoder.ErrUnconfirmedAddress)
+	assert.Equal(t, uint64(3), process.AddressRejections())
+}
+
+func TestMilitaryExtendedSquitter(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+	)
+
+	// Without any parity, a military extended squitter of an unknown aircraft may be noise.
+	require.ErrorIs(t, process.Process(frame(t, "9840621D58C382D690C8AC2863A7")), decoder.ErrUnconfirmedAddress)
+	assert.Equal(t, uint64(1), process.AddressRejections())
+	assert.Empty(t, output.aircraft)
+
+	require.NoError(t, process.Process(frame(t, "8D40621D58C382D690C8AC2863A7")))
+	require.NoError(t, process.Process(frame(t, "9840621D58C382D690C8AC2863A7")))
+	assert.Equal(t, uint64(1), process.AddressRejections())
+
+	require.Len(t, output.aircraft, 2)
+	assert.Equal(t, model.ICAOAddr(0x40621D), output.aircraft[1].Addr)
+}
+
+func TestRepairedFrameAddress(t *testing.T) {
+	t.Parallel()
+
+	output := &transporter{}
+
+	process := decoder.New(
+		context.Background(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		decoder.WithTransporter(output),
+		decoder.WithDatabaseLifetime(time.Minute),
+		decoder.WithErrorCorrector(binary.NewErrorCorrector(1)),
+	)
+
+	// Extended squitter of 0x40621D, with a bit flipped in the message.
+	require.NoError(t, process.Process(frame(t, "8D40621D58C392D690C8AC2863A7")))
+	require.Len(t, output.aircraft, 1)
+	assert.Equal(t, model.ICAOAddr(0x40621D), output.aircraft[0].Addr)
+
+	// The repaired frame does not confirm the address.
+	require.ErrorIs(t, process.Process(frame(t, "02E18C837668DA")), decoder.ErrUnconfirmedAddress)
+	assert.Equal(t, uint64(1), process.AddressRejections())
+}
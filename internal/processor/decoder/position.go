@@ -0,0 +1,298 @@
+package decoder
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/compactposition"
+	"github.com/landru29/adsb1090/internal/database"
+	localerrors "github.com/landru29/adsb1090/internal/errors"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/stats"
+)
+
+const (
+	// ErrPairTooOld is when the odd and even frames are too far apart in time to be paired.
+	ErrPairTooOld localerrors.Error = "odd and even frames too far apart"
+
+	// ErrImpossibleSpeed is when a position implies an impossible speed since the last position.
+	ErrImpossibleSpeed localerrors.Error = "impossible speed since the last position"
+
+	// ErrNoReference is when a surface position has no reference to choose among its 90° apart values.
+	ErrNoReference localerrors.Error = "no reference for the surface position"
+
+	airbornePairWindow = 10 * time.Second
+	surfacePairWindow  = 50 * time.Second
+
+	// surfaceReferenceLifetime is how long the last fix is a reference to decode the surface positions.
+	surfaceReferenceLifetime = 10 * time.Minute
+
+	maxAirborneSpeed = 1000 * 1852.0 / 3600.0 /* 1000 knots in m/s */
+	maxSurfaceSpeed  = 100 * 1852.0 / 3600.0  /* 100 knots in m/s */
+
+	// speedCheckMargin absorbs the inaccuracy of the positions.
+	speedCheckMargin = 1852.0 /* meters */
+)
+
+// PositionRejections counts the rejected positions, by reason.
+type PositionRejections struct {
+	PairTooOld      atomic.Uint64
+	ZoneMismatch    atomic.Uint64
+	OutOfRange      atomic.Uint64
+	ImpossibleSpeed atomic.Uint64
+	NoReference     atomic.Uint64
+	Other           atomic.Uint64
+}
+
+// Total is the number of rejected positions.
+func (r *PositionRejections) Total() uint64 {
+	return r.PairTooOld.Load() +
+		r.ZoneMismatch.Load() +
+		r.OutOfRange.Load() +
+		r.ImpossibleSpeed.Load() +
+		r.NoReference.Load() +
+		r.Other.Load()
+}
+
+func (r *PositionRejections) count(err error) {
+	switch {
+	case errors.Is(err, ErrPairTooOld):
+		r.PairTooOld.Add(1)
+	case errors.Is(err, compactposition.ErrZoneMismatch):
+		r.ZoneMismatch.Add(1)
+	case errors.Is(err, model.ErrOutOfRange):
+		r.OutOfRange.Add(1)
+	case errors.Is(err, ErrImpossibleSpeed):
+		r.ImpossibleSpeed.Add(1)
+	case errors.Is(err, ErrNoReference):
+		r.NoReference.Add(1)
+	default:
+		r.Other.Add(1)
+	}
+}
+
+// PositionFrame is a position frame with its reception date, to pair the frames received close in time.
+type PositionFrame struct {
+	Message    model.Positionner
+	ReceivedAt time.Time
+}
+
+// dated is a position frame of a given kind, with its reception date.
+type dated[T model.Positionner] struct {
+	message    T
+	receivedAt time.Time
+}
+
+// positionFix is the last decoded position of an aircraft.
+type positionFix struct {
+	position *model.Position
+	date     time.Time
+}
+
+// positionDecoder decodes the positions, with safeguards against the wrong odd and even pairs.
+type positionDecoder struct {
+	receiver   *model.Receiver
+	frames     *database.ChainedStorage[model.ICAOAddr, PositionFrame]
+	rejections *PositionRejections
+	stats      *stats.Stats
+}
+
+func newPositionDecoder(
+	ctx context.Context,
+	receiver *model.Receiver,
+	lifetime time.Duration,
+	statistics *stats.Stats,
+) *positionDecoder {
+	return &positionDecoder{
+		receiver: receiver,
+		frames: database.NewChainedStorage[model.ICAOAddr, PositionFrame](
+			ctx,
+			database.ChainedWithLifetime[model.ICAOAddr, PositionFrame](lifetime),
+		),
+		rejections: &PositionRejections{},
+		stats:      statistics,
+	}
+}
+
+// decodeFrame stores the position frame, and decodes the position of the aircraft.
+// The frame is paired with the last frame of the other parity, of the same kind.
+func decodeFrame[T model.Positionner](
+	decoder *positionDecoder,
+	log *slog.Logger,
+	addr model.ICAOAddr,
+	frame T,
+	receivedAt time.Time,
+	previous positionFix,
+	surface bool,
+) *model.Position {
+	decoder.frames.Add(addr, PositionFrame{Message: frame, ReceivedAt: receivedAt})
+
+	var (
+		last  *dated[T]
+		other *dated[T]
+	)
+
+	decoder.frames.ReverseWalk(addr, func(element database.DatedData[PositionFrame]) bool {
+		candidate, ok := element.Data.Message.(T)
+
+		switch {
+		case !ok:
+			return true
+		case last == nil:
+			last = &dated[T]{message: candidate, receivedAt: element.Data.ReceivedAt}
+
+			return true
+		case candidate.OddFrame() != last.message.OddFrame():
+			other = &dated[T]{message: candidate, receivedAt: element.Data.ReceivedAt}
+
+			return false
+		}
+
+		return true
+	})
+
+	if last == nil {
+		return nil
+	}
+
+	position := decodePosition(decoder, log, *last, other, previous, surface)
+	if position != nil {
+		decoder.stats.Position()
+	}
+
+	return position
+}
+
+// decodePosition decodes the last position frame of an aircraft.
+// Odd and even frames are paired when they are close enough in time; with a receiver, a single frame
+// is enough. Surface positions are ambiguous by 90°: they always need the receiver or a previous fix.
+func decodePosition[T model.Positionner](
+	decoder *positionDecoder,
+	log *slog.Logger,
+	last dated[T],
+	other *dated[T],
+	previous positionFix,
+	surface bool,
+) *model.Position {
+	if surface && previous.position == nil && decoder.receiver == nil {
+		decoder.reject(log, ErrNoReference)
+
+		return nil
+	}
+
+	if other != nil {
+		position, err := pairPosition(decoder, last, *other, previous, surface)
+		if err == nil {
+			err = previous.reachable(*position, last.receivedAt, surface)
+		}
+
+		if err == nil {
+			return position
+		}
+
+		decoder.reject(log, err)
+	}
+
+	reference := decoder.reference(previous, last.receivedAt, surface)
+	if reference == nil {
+		return nil
+	}
+
+	position, err := reference.DecodePosition(last.message, surface)
+	if err == nil && decoder.receiver != nil && !decoder.receiver.InRange(*position) {
+		err = model.ErrOutOfRange
+	}
+
+	if err == nil {
+		err = previous.reachable(*position, last.receivedAt, surface)
+	}
+
+	if err != nil {
+		decoder.reject(log, err)
+
+		return nil
+	}
+
+	return position
+}
+
+// reference is the location to decode a single frame: the receiver, or for the surface positions,
+// the recent last fix of the aircraft first.
+func (p *positionDecoder) reference(previous positionFix, date time.Time, surface bool) *model.Receiver {
+	if surface && previous.position != nil && date.Sub(previous.date) <= surfaceReferenceLifetime {
+		return &model.Receiver{Position: *previous.position}
+	}
+
+	return p.receiver
+}
+
+// pairPosition decodes the position with the odd and even frames.
+// The quadrant of the surface positions is the one of the last fix, or of the receiver.
+func pairPosition[T model.Positionner](
+	decoder *positionDecoder,
+	last dated[T],
+	other dated[T],
+	previous positionFix,
+	surface bool,
+) (*model.Position, error) {
+	window := airbornePairWindow
+	if surface {
+		window = surfacePairWindow
+	}
+
+	if last.receivedAt.Sub(other.receivedAt).Abs() > window {
+		return nil, ErrPairTooOld
+	}
+
+	var (
+		position *model.Position
+		err      error
+	)
+
+	switch {
+	case !surface:
+		position, err = model.DecodePosition(last.message, other.message)
+	case previous.position != nil:
+		position, err = model.DecodeSurfacePosition(last.message, other.message, *previous.position)
+	default:
+		position, err = model.DecodeSurfacePosition(last.message, other.message, decoder.receiver.Position)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if decoder.receiver != nil && !decoder.receiver.InRange(*position) {
+		return nil, model.ErrOutOfRange
+	}
+
+	return position, nil
+}
+
+func (p *positionDecoder) reject(log *slog.Logger, err error) {
+	p.rejections.count(err)
+	p.stats.PositionRejection()
+
+	log.Info("position rejected", "msg", err)
+}
+
+// reachable checks that the aircraft could fly from the last fix to the new position.
+func (f positionFix) reachable(position model.Position, date time.Time, surface bool) error {
+	if f.position == nil {
+		return nil
+	}
+
+	maxSpeed := maxAirborneSpeed
+	if surface {
+		maxSpeed = maxSurfaceSpeed
+	}
+
+	if f.position.Distance(position) > speedCheckMargin+maxSpeed*date.Sub(f.date).Abs().Seconds() {
+		return ErrImpossibleSpeed
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 // Package empty is an empty processor.
 package empty
 
+import "github.com/landru29/adsb1090/internal/model"
+
 // New creates an empty processor.
 func New() *Processor {
 	return &Processor{}
@@ -10,6 +12,6 @@ func New() *Processor {
 type Processor struct{}
 
 // Process implements the Processer interface.
-func (e Processor) Process(_ []byte) error {
+func (e Processor) Process(_ model.Frame) error {
 	return nil
 }
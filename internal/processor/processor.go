@@ -1,9 +1,11 @@
 // Package processor defines the way to process input data.
 package processor
 
+import "github.com/landru29/adsb1090/internal/model"
+
 //go:generate mockgen -destination=../mocks/processer.go -package=mocks -source=$GOFILE
 
 // Processer is a data processor.
 type Processer interface {
-	Process(data []byte) error
+	Process(frame model.Frame) error
 }
@@ -2,9 +2,9 @@
 package raw
 
 import (
-	"encoding/hex"
 	"log/slog"
-	"strings"
+
+	"github.com/landru29/adsb1090/internal/model"
 )
 
 // New creates a raw processor.
@@ -18,8 +18,14 @@ type Processor struct {
 }
 
 // Process implements the Processer interface.
-func (e Processor) Process(data []byte) error {
-	e.log.Info("New message", "data", strings.ToUpper(hex.EncodeToString(data)))
+func (e Processor) Process(frame model.Frame) error {
+	e.log.Info(
+		"New message",
+		"data", frame.ModeS.String(),
+		"timestamp", frame.Timestamp,
+		"rssi", frame.RSSI,
+		"corrected", frame.CorrectedBits,
+	)
 
 	return nil
 }
@@ -4,12 +4,22 @@ package basestation
 import (
 	"bytes"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/landru29/adsb1090/internal/model"
 )
 
+const (
+	dateFormat = "2006/01/02"
+	timeFormat = "15:04:05.000"
+)
+
 // Serializer is the BaseStation serializer.
-type Serializer struct{}
+// The format cannot flag the stale values, so they are always omitted.
+type Serializer struct {
+	Staleness model.Staleness
+}
 
 // Serialize implements the Serialize.Serializer interface.
 func (s Serializer) Serialize(planes ...any) ([]byte, error) {
@@ -27,7 +37,12 @@ func (s Serializer) Serialize(planes ...any) ([]byte, error) {
 
 		case *model.Aircraft:
 			if aircraft != nil {
-				output = append(output, []byte(message(*aircraft)))
+				staleness := s.Staleness
+				staleness.Omit = true
+
+				now := time.Now()
+
+				output = append(output, []byte(message(aircraft.Fresh(staleness, now), now)))
 			}
 		case []model.Aircraft:
 			data, err := s.Serialize(model.UntypeArray(aircraft)...)
@@ -59,7 +74,40 @@ func (s Serializer) String() string {
 	return "base-station"
 }
 
-func message(aircraft model.Aircraft) string {
+// dates are the generated and logged dates; the message is generated when the value was received.
+func dates(seen *model.Seen, lastUpdate time.Time, now time.Time) string {
+	generated := lastUpdate
+	if seen != nil {
+		generated = seen.At
+	}
+
+	return strings.Join([]string{
+		generated.Format(dateFormat),
+		generated.Format(timeFormat),
+		now.Format(dateFormat),
+		now.Format(timeFormat),
+	}, ",")
+}
+
+// optional is empty when the value is not available.
+func optional(value *float64) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%1.1f", *value)
+}
+
+// altitude is empty when the altitude is stale.
+func altitude(aircraft model.Aircraft) string {
+	if aircraft.Seen.Altitude != nil && aircraft.Seen.Altitude.Stale {
+		return ""
+	}
+
+	return fmt.Sprintf("%d", int(aircraft.Altitude))
+}
+
+func message(aircraft model.Aircraft, now time.Time) string { //nolint: funlen
 	alert := map[bool]int{
 		false: 0,
 		true:  -1,
@@ -80,34 +128,50 @@ func message(aircraft model.Aircraft) string {
 		true:  -1,
 	}[aircraft.Indent()]
 
+	seen := aircraft.Seen
+
+	// The extended squitters, either from the aircraft or rebroadcasted by the ground stations.
+	squitter := aircraft.LastDownlinkFormat == model.DownlinkFormatExtendedSquitter ||
+		aircraft.LastDownlinkFormat == model.DownlinkFormatExtendedSquitterNonTransponder
+
 	switch {
 	case aircraft.LastDownlinkFormat == 0:
-		return fmt.Sprintf("MSG,5,,,%s,,,,,,,%d,,,,,,,,,,", aircraft.Identity, int(aircraft.Altitude))
+		return fmt.Sprintf("MSG,5,,,%s,,%s,,%s,,,,,,,,,,", aircraft.Addr, dates(seen.Altitude, aircraft.LastUpdate, now), altitude(aircraft)) //nolint: lll
 
 	case aircraft.LastDownlinkFormat == 4:
-		return fmt.Sprintf("MSG,5,,,%s,,,,,,,%d,,,,,,,%d,%d,%d,%d", aircraft.Identity, int(aircraft.Altitude), alert, emergency, spi, ground) //nolint: lll
+		return fmt.Sprintf("MSG,5,,,%s,,%s,,%s,,,,,,,%d,%d,%d,%d", aircraft.Addr, dates(seen.Altitude, aircraft.LastUpdate, now), altitude(aircraft), alert, emergency, spi, ground) //nolint: lll
 
 	case aircraft.LastDownlinkFormat == 5:
-		return fmt.Sprintf("MSG,6,,,%s,,,,,,,,,,,,,%d,%d,%d,%d,%d", aircraft.Identity, aircraft.Identity, alert, emergency, spi, ground) //nolint: lll
+		return fmt.Sprintf("MSG,6,,,%s,,%s,,,,,,,,%d,%d,%d,%d,%d", aircraft.Addr, dates(seen.Squawk, aircraft.LastUpdate, now), aircraft.Identity, alert, emergency, spi, ground) //nolint: lll
 
 	case aircraft.LastDownlinkFormat == 11:
-		return fmt.Sprintf("MSG,8,,,%s,,,,,,,,,,,,,,,,,", aircraft.Identity)
+		return fmt.Sprintf("MSG,8,,,%s,,%s,,,,,,,,,,,,", aircraft.Addr, dates(nil, aircraft.LastUpdate, now))
+
+	case squitter && aircraft.LastType == 4:
+		return fmt.Sprintf("MSG,1,,,%s,,%s,%s,,,,,,,,0,%d,0,0", aircraft.Addr, dates(seen.Identification, aircraft.LastUpdate, now), strings.TrimSpace(aircraft.Identification), emergency) //nolint: lll
+
+	case squitter && aircraft.LastType >= 5 && aircraft.LastType <= 8:
+		if aircraft.Position == nil {
+			return fmt.Sprintf("MSG,2,,,%s,,%s,,,%s,%s,,,,,,,,%d", aircraft.Addr, dates(seen.Velocity, aircraft.LastUpdate, now), optional(aircraft.GroundSpeed), optional(aircraft.Track), ground) //nolint: lll
+		}
 
-	case aircraft.LastDownlinkFormat == 17 && aircraft.LastType == 4:
-		return fmt.Sprintf("MSG,1,,,%s,,,,,,%s,,,,,,,,0,0,0,0", aircraft.Identity, aircraft.Flight)
+		return fmt.Sprintf("MSG,2,,,%s,,%s,,,%s,%s,%1.5f,%1.5f,,,,,,%d", aircraft.Addr, dates(seen.Position, aircraft.LastUpdate, now), optional(aircraft.GroundSpeed), optional(aircraft.Track), aircraft.Position.Latitude, aircraft.Position.Longitude, ground) //nolint: lll
 
-	case aircraft.LastDownlinkFormat == 17 && aircraft.LastType >= 8 && aircraft.LastType <= 18:
+	case squitter && aircraft.LastType >= 9 && aircraft.LastType <= 18:
 		if aircraft.Position == nil {
-			return fmt.Sprintf("MSG,3,,,%s,,,,,,,%d,,,,,,,0,0,0,0", aircraft.Identity, int(aircraft.Altitude))
+			return fmt.Sprintf("MSG,3,,,%s,,%s,,%s,,,,,,,0,%d,0,0", aircraft.Addr, dates(seen.Altitude, aircraft.LastUpdate, now), altitude(aircraft), emergency) //nolint: lll
 		}
 
-		return fmt.Sprintf("MSG,3,,,%s,,,,,,,%d,,,%1.5f,%1.5f,,,0,0,0,0", aircraft.Identity, int(aircraft.Altitude), aircraft.Position.Latitude, aircraft.Position.Longitude) //nolint: lll
+		return fmt.Sprintf("MSG,3,,,%s,,%s,,%s,,,%1.5f,%1.5f,,,0,%d,0,0", aircraft.Addr, dates(seen.Position, aircraft.LastUpdate, now), altitude(aircraft), aircraft.Position.Latitude, aircraft.Position.Longitude, emergency) //nolint: lll
+
+	case squitter && aircraft.LastType == 19:
+		return fmt.Sprintf("MSG,4,,,%s,,%s,,,%s,%s,,,%d,,0,%d,0,0", aircraft.Addr, dates(seen.Velocity, aircraft.LastUpdate, now), optional(aircraft.GroundSpeed), optional(aircraft.Track), aircraft.VerticalRate, emergency) //nolint: lll
 
-	case aircraft.LastDownlinkFormat == 17 && aircraft.LastType == 19 && aircraft.LastSubType == 1:
-		return fmt.Sprintf("MSG,4,,,%s,,,,,,,,%d,%d,,,%d,,0,0,0,0", aircraft.Identity, aircraft.GroundSpeed, aircraft.Track, aircraft.VerticalRate) //nolint: lll
+	case squitter && aircraft.LastType == 28:
+		return fmt.Sprintf("MSG,6,,,%s,,%s,,,,,,,,%d,0,%d,0,0", aircraft.Addr, dates(seen.Squawk, aircraft.LastUpdate, now), aircraft.Identity, emergency) //nolint: lll
 
 	case aircraft.LastDownlinkFormat == 21:
-		return fmt.Sprintf("MSG,6,,,%s,,,,,,,,,,,,,%d,%d,%d,%d,%d", aircraft.Identity, aircraft.Identity, alert, emergency, spi, ground) //nolint: lll
+		return fmt.Sprintf("MSG,6,,,%s,,%s,,,,,,,,%d,%d,%d,%d,%d", aircraft.Addr, dates(seen.Squawk, aircraft.LastUpdate, now), aircraft.Identity, alert, emergency, spi, ground) //nolint: lll
 	}
 
 	return ""
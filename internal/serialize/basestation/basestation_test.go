@@ -0,0 +1,85 @@
+package basestation_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/serialize/basestation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerialize(t *testing.T) {
+	t.Parallel()
+
+	heard := time.Date(2024, 5, 1, 12, 0, 30, 0, time.UTC)
+
+	for idx, fixtureElt := range []struct {
+		name     string
+		aircraft model.Aircraft
+		expected []string /* Fields before the logged date, and after it. */
+	}{
+		{
+			name: "identification",
+			aircraft: model.Aircraft{
+				Addr:               0x4840d6,
+				Identification:     "KLM1023 ",
+				LastDownlinkFormat: model.DownlinkFormatExtendedSquitter,
+				LastType:           4,
+				LastUpdate:         heard.Add(time.Second),
+				Seen:               model.FieldsSeen{Identification: &model.Seen{At: heard}},
+			},
+			expected: []string{
+				"MSG,1,,,4840D6,,2024/05/01,12:00:30.000",
+				"KLM1023,,,,,,,,0,0,0,0",
+			},
+		},
+		{
+			name: "airborne position",
+			aircraft: model.Aircraft{
+				Addr:               0x40621d,
+				Altitude:           38000,
+				Position:           &model.Position{Latitude: 52.2572, Longitude: 3.9194},
+				LastDownlinkFormat: model.DownlinkFormatExtendedSquitter,
+				LastType:           18,
+				LastUpdate:         heard,
+			},
+			expected: []string{
+				"MSG,3,,,40621D,,2024/05/01,12:00:30.000",
+				",38000,,,52.25720,3.91940,,,0,0,0,0",
+			},
+		},
+		{
+			name: "surveillance altitude",
+			aircraft: model.Aircraft{
+				Addr:               0x4d2023,
+				Altitude:           23375,
+				LastDownlinkFormat: 4,
+				LastUpdate:         heard,
+			},
+			expected: []string{
+				"MSG,5,,,4D2023,,2024/05/01,12:00:30.000",
+				",23375,,,,,,,0,0,0,0",
+			},
+		},
+	} {
+		fixture := fixtureElt
+
+		t.Run(fmt.Sprintf("%d: %s", idx, fixture.name), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := basestation.Serializer{}.Serialize(fixture.aircraft)
+			require.NoError(t, err)
+
+			fields := strings.Split(string(data), ",")
+			require.Len(t, fields, 22)
+
+			// The logged date is the serialization date.
+			assert.Equal(t, fixture.expected[0], strings.Join(fields[:8], ","))
+			assert.Equal(t, fixture.expected[1], strings.Join(fields[10:], ","))
+		})
+	}
+}
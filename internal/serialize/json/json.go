@@ -3,12 +3,15 @@ package json
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/landru29/adsb1090/internal/model"
 )
 
 // Serializer is the json serializer.
-type Serializer struct{}
+type Serializer struct {
+	Staleness model.Staleness
+}
 
 // Serialize implements the Serialize.Serializer interface.
 func (s Serializer) Serialize(planes ...any) ([]byte, error) {
@@ -32,6 +35,12 @@ func (s Serializer) Serialize(planes ...any) ([]byte, error) {
 		}
 	}
 
+	now := time.Now()
+
+	for idx := range output {
+		output[idx] = output[idx].Fresh(s.Staleness, now)
+	}
+
 	return json.Marshal(output)
 }
 
@@ -2,12 +2,16 @@ package nmea
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/landru29/adsb1090/internal/model"
 )
 
 const (
 	speedOverGroundScale = 10
+
+	// trueHeadingNotAvailable is the AIS default heading; the aircraft only report a magnetic heading.
+	trueHeadingNotAvailable = 511
 )
 
 // VesselType is a type of vessel.
@@ -21,16 +25,21 @@ const (
 )
 
 // Serializer is the nmea serializer.
+// The format cannot flag the stale values, so they are always omitted.
 type Serializer struct {
 	mmsiVessel VesselType
 	mid        uint16
+	staleness  model.Staleness
 }
 
 // New is a new NMEA serializer.
-func New(mmsiVessel VesselType, mid uint16) *Serializer {
+func New(mmsiVessel VesselType, mid uint16, staleness model.Staleness) *Serializer {
+	staleness.Omit = true
+
 	return &Serializer{
 		mmsiVessel: mmsiVessel,
 		mid:        mid,
+		staleness:  staleness,
 	}
 }
 
@@ -49,8 +58,14 @@ func (s Serializer) Serialize(planes ...any) ([]byte, error) {
 			output = append(output, data)
 
 		case *model.Aircraft:
-			if aircraft != nil && aircraft.Position != nil {
-				fields, err := s.fieldFromAircraft(aircraft)
+			if aircraft == nil {
+				continue
+			}
+
+			fresh := aircraft.Fresh(s.staleness, time.Now())
+
+			if fresh.Position != nil {
+				fields, err := s.fieldFromAircraft(&fresh)
 				if err != nil {
 					return nil, err
 				}
@@ -84,10 +99,7 @@ func (s Serializer) fieldFromAircraft(aircraft *model.Aircraft) (fields, error)
 		Latitude:         aircraft.Position.Latitude,
 		PositionAccuracy: true,
 		NavigationStatus: navigationStatusAground,
-	}
-
-	if aircraft.AirSpeed != nil {
-		currentPayload.SpeedOverGround = *aircraft.AirSpeed / speedOverGroundScale
+		TrueHeading:      trueHeadingNotAvailable,
 	}
 
 	if aircraft.GroundSpeed != nil {
@@ -96,7 +108,6 @@ func (s Serializer) fieldFromAircraft(aircraft *model.Aircraft) (fields, error)
 
 	if aircraft.Track != nil {
 		currentPayload.CourseOverGround = *aircraft.Track
-		currentPayload.TrueHeading = uint16(*aircraft.Track)
 	}
 
 	return currentPayload.Fields()
@@ -118,7 +129,7 @@ func (s Serializer) MMSI(addr model.ICAOAddr) uint32 {
 		out += 5000
 	}
 
-	return out + uint32(addr)%1000
+	return out + addr.Number()%1000
 }
 
 // String implements the Serialize.Serializer interface.
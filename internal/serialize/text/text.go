@@ -3,12 +3,15 @@ package text
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/landru29/adsb1090/internal/model"
 )
 
 // Serializer is the text serializer.
-type Serializer struct{}
+type Serializer struct {
+	Staleness model.Staleness
+}
 
 // Serialize implements the Serialize.Serializer interface.
 func (s Serializer) Serialize(planes ...any) ([]byte, error) {
@@ -26,7 +29,7 @@ func (s Serializer) Serialize(planes ...any) ([]byte, error) {
 
 		case *model.Aircraft:
 			if aircraft != nil {
-				output = append(output, []byte(aircraft.String()))
+				output = append(output, []byte(aircraft.Fresh(s.Staleness, time.Now()).String()))
 			}
 		case []model.Aircraft:
 			data, err := s.Serialize(model.UntypeArray(aircraft)...)
@@ -0,0 +1,375 @@
+// Package stats counts the receiver activity, by interval.
+package stats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/model"
+)
+
+const (
+	// bucketDuration is the duration of a bucket; the intervals are made of the last buckets.
+	bucketDuration = time.Minute
+
+	// bucketCount is the number of buckets kept: the longest interval, and the current one.
+	bucketCount = 16
+)
+
+// Counters is the receiver activity over an interval.
+type Counters struct {
+	Preambles          uint64                          `json:"preambles"`          /* Preambles detected by the demodulators. */
+	Frames             uint64                          `json:"frames"`             /* Frames received by the decoder, from any input. */
+	CRCFailures        uint64                          `json:"crcFailures"`        /* Frames dropped, with a wrong parity. */
+	Corrected          uint64                          `json:"corrected"`          /* Frames repaired. */
+	AddressRejections  uint64                          `json:"addressRejections"`  /* Address/parity frames of unconfirmed aircraft. */
+	Messages           map[model.DownlinkFormat]uint64 `json:"messages"`           /* Accepted messages, by downlink format. */
+	TypeCodes          map[model.TypeCode]uint64       `json:"typeCodes"`          /* Extended squitters, by type code. */
+	Aircraft           int                             `json:"aircraft"`           /* Unique aircraft addresses. */
+	Positions          uint64                          `json:"positions"`          /* Positions decoded. */
+	PositionRejections uint64                          `json:"positionRejections"` /* Positions rejected. */
+	Transported        uint64                          `json:"transported"`        /* Aircraft sent to the transporters. */
+	TransportErrors    uint64                          `json:"transportErrors"`
+	TransporterErrors  map[string]uint64               `json:"transporterErrors"` /* Transport errors, by transporter. */
+
+	addresses map[model.ICAOAddr]struct{}
+}
+
+func newCounters() *Counters {
+	return &Counters{
+		Messages:          map[model.DownlinkFormat]uint64{},
+		TypeCodes:         map[model.TypeCode]uint64{},
+		TransporterErrors: map[string]uint64{},
+		addresses:         map[model.ICAOAddr]struct{}{},
+	}
+}
+
+// MessageCount is the number of accepted messages.
+func (c Counters) MessageCount() uint64 {
+	count := uint64(0)
+
+	for _, value := range c.Messages {
+		count += value
+	}
+
+	return count
+}
+
+// add merges the counters.
+func (c *Counters) add(other *Counters) {
+	c.Preambles += other.Preambles
+	c.Frames += other.Frames
+	c.CRCFailures += other.CRCFailures
+	c.Corrected += other.Corrected
+	c.AddressRejections += other.AddressRejections
+	c.Positions += other.Positions
+	c.PositionRejections += other.PositionRejections
+	c.Transported += other.Transported
+	c.TransportErrors += other.TransportErrors
+
+	for key, value := range other.Messages {
+		c.Messages[key] += value
+	}
+
+	for key, value := range other.TypeCodes {
+		c.TypeCodes[key] += value
+	}
+
+	for key, value := range other.TransporterErrors {
+		c.TransporterErrors[key] += value
+	}
+
+	for key := range other.addresses {
+		c.addresses[key] = struct{}{}
+	}
+
+	c.Aircraft = len(c.addresses)
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (c Counters) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("preambles", c.Preambles),
+		slog.Uint64("frames", c.Frames),
+		slog.Uint64("crcFailures", c.CRCFailures),
+		slog.Uint64("corrected", c.Corrected),
+		slog.Uint64("addressRejections", c.AddressRejections),
+		slog.Uint64("messages", c.MessageCount()),
+		slog.Int("aircraft", c.Aircraft),
+		slog.Uint64("positions", c.Positions),
+		slog.Uint64("positionRejections", c.PositionRejections),
+		slog.Uint64("transportErrors", c.TransportErrors),
+	)
+}
+
+// Report is the receiver activity over the last intervals.
+type Report struct {
+	Since         time.Time `json:"since"`
+	LastMinute    Counters  `json:"lastMinute"`
+	Last5Minutes  Counters  `json:"last5Minutes"`
+	Last15Minutes Counters  `json:"last15Minutes"`
+	Total         Counters  `json:"total"`
+}
+
+// bucket is the activity during one minute.
+type bucket struct {
+	index    int64 /* Minutes since the epoch. */
+	counters *Counters
+}
+
+// Configurator is the Stats configurator.
+type Configurator func(*Stats)
+
+// Stats records the receiver activity. All the methods are safe on a nil Stats.
+type Stats struct {
+	mutex   sync.Mutex
+	now     func() time.Time
+	since   time.Time
+	buckets [bucketCount]bucket
+	total   *Counters
+}
+
+// New creates the statistics.
+func New(opts ...Configurator) *Stats {
+	output := &Stats{
+		now:   time.Now,
+		total: newCounters(),
+	}
+
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	output.since = output.now()
+
+	return output
+}
+
+// WithClock sets the clock, to date the activity.
+func WithClock(now func() time.Time) Configurator {
+	return func(s *Stats) {
+		s.now = now
+	}
+}
+
+// record applies the update to the current bucket, and to the total.
+func (s *Stats) record(update func(*Counters)) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	index := s.now().UnixNano() / int64(bucketDuration)
+
+	current := &s.buckets[index%bucketCount]
+	if current.counters == nil || current.index != index {
+		current.index = index
+		current.counters = newCounters()
+	}
+
+	update(current.counters)
+	update(s.total)
+}
+
+// Preamble counts a preamble detected by a demodulator.
+func (s *Stats) Preamble() {
+	s.record(func(c *Counters) { c.Preambles++ })
+}
+
+// Frame counts a frame received by the decoder.
+func (s *Stats) Frame() {
+	s.record(func(c *Counters) { c.Frames++ })
+}
+
+// CRCFailure counts a frame with a wrong parity.
+func (s *Stats) CRCFailure() {
+	s.record(func(c *Counters) { c.CRCFailures++ })
+}
+
+// Corrected counts a repaired frame.
+func (s *Stats) Corrected() {
+	s.record(func(c *Counters) { c.Corrected++ })
+}
+
+// AddressRejection counts an address/parity frame of an unconfirmed aircraft.
+func (s *Stats) AddressRejection() {
+	s.record(func(c *Counters) { c.AddressRejections++ })
+}
+
+// Message counts an accepted message, and its aircraft.
+func (s *Stats) Message(message model.QualifiedMessage) {
+	downlinkFormat := message.DownlinkFormat()
+	addr := message.AircraftAddress()
+
+	squitter, extended := message.(model.ExtendedSquitter)
+
+	s.record(func(c *Counters) {
+		c.Messages[downlinkFormat]++
+
+		// The type codes are counted without grouping them.
+		if extended {
+			c.TypeCodes[model.TypeCode(squitter.ModeS[4]>>3)]++ //nolint: gomnd
+		}
+
+		c.addresses[addr] = struct{}{}
+		c.Aircraft = len(c.addresses)
+	})
+}
+
+// Position counts a decoded position.
+func (s *Stats) Position() {
+	s.record(func(c *Counters) { c.Positions++ })
+}
+
+// PositionRejection counts a rejected position.
+func (s *Stats) PositionRejection() {
+	s.record(func(c *Counters) { c.PositionRejections++ })
+}
+
+// Transported counts an aircraft sent to a transporter, and its error.
+func (s *Stats) Transported(name string, err error) {
+	s.record(func(c *Counters) {
+		c.Transported++
+
+		if err != nil {
+			c.TransportErrors++
+			c.TransporterErrors[name]++
+		}
+	})
+}
+
+// Report is the activity over the last 1, 5 and 15 minutes, and since the start.
+// The intervals are made of the last complete minutes: the current one is only in the total.
+func (s *Stats) Report() Report {
+	if s == nil {
+		return Report{}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	index := s.now().UnixNano() / int64(bucketDuration)
+
+	output := Report{
+		Since: s.since,
+		Total: *newCounters(),
+	}
+
+	output.Total.add(s.total)
+
+	for _, elt := range []struct {
+		counters *Counters
+		minutes  int64
+	}{
+		{counters: &output.LastMinute, minutes: 1},
+		{counters: &output.Last5Minutes, minutes: 5},   //nolint: gomnd
+		{counters: &output.Last15Minutes, minutes: 15}, //nolint: gomnd
+	} {
+		*elt.counters = *newCounters()
+
+		for _, current := range s.buckets {
+			if age := index - current.index; current.counters != nil && age >= 1 && age <= elt.minutes {
+				elt.counters.add(current.counters)
+			}
+		}
+	}
+
+	return output
+}
+
+// Log periodically writes the activity of the last minute, until the context is done.
+func (s *Stats) Log(ctx context.Context, log *slog.Logger, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report := s.Report()
+
+			log.Info("statistics", "lastMinute", report.LastMinute, "total", report.Total)
+		}
+	}
+}
+
+// String implements the Stringer interface.
+func (r Report) String() string {
+	intervals := []Counters{r.LastMinute, r.Last5Minutes, r.Last15Minutes, r.Total}
+
+	output := &strings.Builder{}
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', tabwriter.AlignRight) //nolint: gomnd
+
+	row := func(name string, value func(Counters) uint64) {
+		fmt.Fprint(writer, name, "\t")
+
+		for _, counters := range intervals {
+			fmt.Fprint(writer, value(counters), "\t")
+		}
+
+		fmt.Fprintln(writer)
+	}
+
+	fmt.Fprintf(writer, "since %s\t1 min\t5 min\t15 min\ttotal\t\n", r.Since.Format(time.RFC3339))
+
+	row("preambles", func(c Counters) uint64 { return c.Preambles })
+	row("frames", func(c Counters) uint64 { return c.Frames })
+	row("CRC failures", func(c Counters) uint64 { return c.CRCFailures })
+	row("corrected", func(c Counters) uint64 { return c.Corrected })
+	row("address rejections", func(c Counters) uint64 { return c.AddressRejections })
+	row("messages", func(c Counters) uint64 { return c.MessageCount() })
+
+	for _, downlinkFormat := range keys(r.Total.Messages) {
+		row(fmt.Sprintf("DF%d", downlinkFormat), func(c Counters) uint64 { return c.Messages[downlinkFormat] })
+	}
+
+	for _, typeCode := range keys(r.Total.TypeCodes) {
+		row(fmt.Sprintf("TC%d", typeCode), func(c Counters) uint64 { return c.TypeCodes[typeCode] })
+	}
+
+	row("aircraft", func(c Counters) uint64 { return uint64(c.Aircraft) })
+	row("positions", func(c Counters) uint64 { return c.Positions })
+	row("position rejections", func(c Counters) uint64 { return c.PositionRejections })
+	row("transported", func(c Counters) uint64 { return c.Transported })
+	row("transport errors", func(c Counters) uint64 { return c.TransportErrors })
+
+	_ = writer.Flush()
+
+	return output.String()
+}
+
+// keys are the sorted keys of a counter map.
+func keys[K ~uint8](data map[K]uint64) []K {
+	output := make([]K, 0, len(data))
+
+	for key := range data {
+		output = append(output, key)
+	}
+
+	sort.Slice(output, func(i, j int) bool { return output[i] < output[j] })
+
+	return output
+}
+
+type statsContext struct{}
+
+// WithStats sets the statistics in the context.
+func WithStats(ctx context.Context, stats *Stats) context.Context {
+	return context.WithValue(ctx, statsContext{}, stats)
+}
+
+// FromContext gets the statistics from the context.
+func FromContext(ctx context.Context) (*Stats, bool) {
+	out, found := ctx.Value(statsContext{}).(*Stats)
+
+	return out, found
+}
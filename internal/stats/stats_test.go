@@ -0,0 +1,102 @@
+package stats_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func message(t *testing.T, str string) model.QualifiedMessage { //nolint: ireturn
+	t.Helper()
+
+	dataByte, err := hex.DecodeString(str)
+	require.NoError(t, err)
+
+	squitter, err := model.ModeS(dataByte).QualifiedMessage()
+	require.NoError(t, err)
+
+	return squitter
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 12, 0, 30, 0, time.UTC)
+
+	statistics := stats.New(stats.WithClock(func() time.Time { return now }))
+
+	// 14 minutes ago.
+	statistics.Frame()
+	statistics.Message(message(t, "8D4840D6202CC371C32CE0576098"))
+
+	now = now.Add(11 * time.Minute)
+
+	statistics.Frame()
+	statistics.CRCFailure()
+
+	now = now.Add(3 * time.Minute)
+
+	statistics.Frame()
+	statistics.Corrected()
+	statistics.Message(message(t, "8D40621D58C382D690C8AC2863A7"))
+	statistics.Message(message(t, "5D40621D4F94D0"))
+	statistics.Position()
+	statistics.Transported("http", nil)
+	statistics.Transported("tcp", errors.New("broken pipe"))
+
+	// The current minute is not complete.
+	report := statistics.Report()
+
+	assert.Zero(t, report.LastMinute.Frames)
+	assert.Equal(t, uint64(3), report.Total.Frames)
+
+	now = now.Add(40 * time.Second)
+
+	report = statistics.Report()
+
+	assert.Equal(t, uint64(1), report.LastMinute.Frames)
+	assert.Equal(t, uint64(1), report.LastMinute.Corrected)
+	assert.Equal(t, uint64(1), report.LastMinute.Positions)
+	assert.Equal(t, 1, report.LastMinute.Aircraft)
+	assert.Equal(t, uint64(2), report.LastMinute.MessageCount())
+	assert.Equal(t, uint64(1), report.LastMinute.Messages[model.DownlinkFormatAllCallReply])
+	assert.Equal(t, uint64(1), report.LastMinute.TypeCodes[model.TypeCode(11)])
+	assert.Equal(t, uint64(2), report.LastMinute.Transported)
+	assert.Equal(t, map[string]uint64{"tcp": 1}, report.LastMinute.TransporterErrors)
+
+	assert.Equal(t, uint64(2), report.Last5Minutes.Frames)
+	assert.Equal(t, uint64(1), report.Last5Minutes.CRCFailures)
+
+	assert.Equal(t, uint64(3), report.Last15Minutes.Frames)
+	assert.Equal(t, 2, report.Last15Minutes.Aircraft)
+
+	// The first minute is out of the window.
+	now = now.Add(time.Minute)
+
+	report = statistics.Report()
+
+	assert.Zero(t, report.LastMinute.Frames)
+	assert.Equal(t, uint64(2), report.Last15Minutes.Frames)
+	assert.Equal(t, uint64(3), report.Total.Frames)
+	assert.Equal(t, 2, report.Total.Aircraft)
+
+	assert.Contains(t, report.String(), "DF17")
+	assert.Contains(t, report.String(), "TC11")
+}
+
+func TestNilStats(t *testing.T) {
+	t.Parallel()
+
+	var statistics *stats.Stats
+
+	statistics.Frame()
+	statistics.Transported("http", nil)
+
+	assert.Zero(t, statistics.Report().Total.Frames)
+}
@@ -4,15 +4,20 @@ package http
 import (
 	"context"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"net/http"
+	"path"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/landru29/adsb1090/internal/database"
 	"github.com/landru29/adsb1090/internal/model"
 	"github.com/landru29/adsb1090/internal/serialize"
+	"github.com/landru29/adsb1090/internal/stats"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 //go:embed public/*
@@ -22,10 +27,31 @@ const (
 	readHeaderTimeout time.Duration = time.Second * 10
 )
 
+// Configurator is the http transporter configurator.
+type Configurator func(*Transporter)
+
 // Transporter is the http transporter.
 type Transporter struct {
 	aircraftDB *database.ElementStorage[model.ICAOAddr, model.Aircraft]
 	formaters  map[string]serialize.Serializer
+	router     *mux.Router
+	stats      *stats.Stats
+	metrics    prometheus.Collector
+	stream     *stream
+}
+
+// WithStats serves the receiver statistics, on the 'stats' path of the API.
+func WithStats(statistics *stats.Stats) Configurator {
+	return func(t *Transporter) {
+		t.stats = statistics
+	}
+}
+
+// WithMetrics serves the Prometheus metrics, on the '/metrics' path.
+func WithMetrics(collector prometheus.Collector) Configurator {
+	return func(t *Transporter) {
+		t.metrics = collector
+	}
 }
 
 // New creates an http transporter.
@@ -35,6 +61,7 @@ func New(
 	apiPath string,
 	aircraftDB *database.ElementStorage[model.ICAOAddr, model.Aircraft],
 	formaters []serialize.Serializer,
+	opts ...Configurator,
 ) (*Transporter, error) {
 	subFS, _ := fs.Sub(staticFiles, "public")
 
@@ -47,9 +74,30 @@ func New(
 		output.formaters[elt.MimeType()] = elt
 	}
 
+	output.stream = newStream(aircraftDB, output.formaters["application/json"])
+
+	for _, opt := range opts {
+		opt(&output)
+	}
+
 	router := mux.NewRouter()
+	output.router = router
 
 	router.HandleFunc(apiPath, output.serveData)
+	router.HandleFunc(path.Join(apiPath, "stream"), output.serveStream)
+
+	if output.stats != nil {
+		router.HandleFunc(path.Join(apiPath, "stats"), output.serveStats)
+	}
+
+	if output.metrics != nil {
+		registry := prometheus.NewRegistry()
+		if err := registry.Register(output.metrics); err != nil {
+			return nil, err
+		}
+
+		router.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	}
 
 	router.HandleFunc("/config.js", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(fmt.Sprintf("window.apiPath='%s'", apiPath)))
@@ -75,6 +123,7 @@ func New(
 
 	go func() {
 		<-ctx.Done()
+		output.stream.close()
 		_ = srv.Shutdown(ctx)
 	}()
 
@@ -82,8 +131,15 @@ func New(
 }
 
 // Transport implements the transport.Transporter interface.
-func (t *Transporter) Transport(_ *model.Aircraft) error {
-	return nil
+// The aircraft is stored until it was not updated for the database lifetime,
+// and its changes are pushed to the stream subscribers.
+func (t *Transporter) Transport(ac *model.Aircraft) error {
+	return t.stream.update(ac)
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (t *Transporter) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	t.router.ServeHTTP(writer, req)
 }
 
 func (t *Transporter) serveData(writer http.ResponseWriter, req *http.Request) {
@@ -96,7 +152,10 @@ func (t *Transporter) serveData(writer http.ResponseWriter, req *http.Request) {
 
 	dataArray := []*model.Aircraft{}
 	for _, addr := range t.aircraftDB.Keys() {
-		dataArray = append(dataArray, t.aircraftDB.Element(addr))
+		// the element may have expired since the keys were listed.
+		if aircraft := t.aircraftDB.Element(addr); aircraft != nil {
+			dataArray = append(dataArray, aircraft)
+		}
 	}
 
 	output, err := formater.Serialize(dataArray)
@@ -108,6 +167,18 @@ func (t *Transporter) serveData(writer http.ResponseWriter, req *http.Request) {
 	_, _ = writer.Write(output)
 }
 
+func (t *Transporter) serveStats(writer http.ResponseWriter, _ *http.Request) {
+	output, err := json.Marshal(t.stats.Report())
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("content-type", "application/json")
+	_, _ = writer.Write(output)
+}
+
 // String implements the transport.Transporter interface.
 func (t *Transporter) String() string {
 	return "http"
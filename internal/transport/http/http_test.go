@@ -0,0 +1,124 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/database"
+	"github.com/landru29/adsb1090/internal/input/demodulator"
+	"github.com/landru29/adsb1090/internal/input/implementations"
+	"github.com/landru29/adsb1090/internal/metrics"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/processor/decoder"
+	"github.com/landru29/adsb1090/internal/serialize"
+	jsonserializer "github.com/landru29/adsb1090/internal/serialize/json"
+	"github.com/landru29/adsb1090/internal/stats"
+	transporthttp "github.com/landru29/adsb1090/internal/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	statistics := stats.New()
+
+	aircraftDB := database.NewElementStorage[model.ICAOAddr, model.Aircraft](
+		ctx,
+		database.ElementWithLifetime[model.ICAOAddr, model.Aircraft](time.Minute),
+		database.ElementWithCleanCycle[model.ICAOAddr, model.Aircraft](time.Minute),
+	)
+
+	transporter, err := transporthttp.New(
+		ctx,
+		"127.0.0.1:0",
+		"/api",
+		aircraftDB,
+		[]serialize.Serializer{jsonserializer.Serializer{}},
+		transporthttp.WithStats(statistics),
+		transporthttp.WithMetrics(metrics.New(
+			metrics.WithStats(statistics),
+			metrics.WithAircraftDatabase(aircraftDB),
+		)),
+	)
+	require.NoError(t, err)
+
+	file, err := os.Open("../../../testdata/modes1.bin")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = file.Close()
+	})
+
+	require.NoError(t, implementations.NewReader(file, demodulator.WithStats(statistics)).Start(
+		ctx,
+		decoder.New(
+			ctx,
+			log,
+			decoder.WithDatabaseLifetime(time.Minute),
+			decoder.WithTransporter(transporter),
+			decoder.WithStats(statistics),
+		),
+	))
+
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	req.Header.Set("Accept", "application/json")
+
+	recorder := httptest.NewRecorder()
+
+	transporter.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	aircraft := []model.Aircraft{}
+
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &aircraft))
+
+	assert.NotEmpty(t, aircraft)
+	assert.Len(t, aircraft, len(aircraftDB.Keys()))
+
+	for _, elt := range aircraft {
+		assert.NotZero(t, elt.Addr)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/stats", nil)
+
+	recorder = httptest.NewRecorder()
+
+	transporter.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	report := stats.Report{}
+
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+
+	assert.Positive(t, report.Total.Preambles)
+	assert.Positive(t, report.Total.Frames)
+	assert.NotEmpty(t, report.Total.Messages)
+	assert.Equal(t, len(aircraft), report.Total.Aircraft)
+
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+
+	recorder = httptest.NewRecorder()
+
+	transporter.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	assert.Contains(t, recorder.Body.String(), `adsb1090_messages_total{df="17"}`)
+	assert.Contains(t, recorder.Body.String(), fmt.Sprintf("adsb1090_aircraft %d\n", len(aircraftDB.Keys())))
+}
@@ -23,78 +23,116 @@ window.addEventListener('load', function() {
 
 	const acContainer = document.getElementById("ac");
 
-	aircraftGetter = function() {
-		loadAircrafts().then(function(aircraftList) {
-			aircraftList.forEach((elt) => {
-				elt.seen = new Date();
-				const oldAC = aircraftDict[elt.icao];
-	
-				
-				if (oldAC != undefined) {
-					aircraftDict[elt.icao] = Object.assign(oldAC, elt);
-				} else {
-					aircraftDict[elt.icao] = elt;
-					const container = document.createElement('div');
-					container.className = 'aircraft';
-					container.id = `icao_${elt.icao}`;
-					aircraftDict[elt.icao].container = container;
-					acContainer.appendChild(container);
-					container.addEventListener('click', () => clickAircraft(map, elt.icao));
-				}
-	
-				if (aircraftDict[elt.icao].lat != 0 && aircraftDict[elt.icao].lon != 0) {
-					const coord = new L.LatLng(aircraftDict[elt.icao].lat, aircraftDict[elt.icao].lon);
-					aircraftDict[elt.icao].coordinate = coord;
-					if (aircraftDict[elt.icao].marker == undefined) {
-						aircraftDict[elt.icao].marker = L.marker(coord, {icon: aircraftMarkerIcon, icao: elt.icao}).addTo(map);
-						aircraftDict[elt.icao].marker.on('click', ()=>{
-							clickAircraft(map, elt.icao);
-						});
-					}
-					
-					aircraftDict[elt.icao].marker.setLatLng(coord); 
-					aircraftDict[elt.icao].marker.setRotationAngle(elt.track);
-				}
-			});
-			
-			// clean outdated aircrafts.
-			Object.keys(aircraftDict).forEach((key)=>{
-				if (aircraftDict[key].seen.getTime() < new Date().getTime()- 120000) {
-					acContainer.removeChild(aircraftDict[key].container);
-					delete aircraftDict[key];
+	// merge the aircraft, either complete or only its changed fields.
+	const updateAircraft = function(elt) {
+		const oldAC = aircraftDict[elt.icao];
+
+		if (oldAC != undefined) {
+			aircraftDict[elt.icao] = Object.assign(oldAC, elt);
+		} else {
+			aircraftDict[elt.icao] = elt;
+			const container = document.createElement('div');
+			container.className = 'aircraft';
+			container.id = `icao_${elt.icao}`;
+			aircraftDict[elt.icao].container = container;
+			acContainer.appendChild(container);
+			container.addEventListener('click', () => clickAircraft(map, elt.icao));
+		}
+
+		const aircraft = aircraftDict[elt.icao];
+		aircraft.seen = new Date();
+
+		if (aircraft.position) {
+			const coord = new L.LatLng(aircraft.position.lat, aircraft.position.lng);
+			aircraft.coordinate = coord;
+			if (aircraft.marker == undefined) {
+				aircraft.marker = L.marker(coord, {icon: aircraftMarkerIcon, icao: elt.icao}).addTo(map);
+				aircraft.marker.on('click', ()=>{
+					clickAircraft(map, elt.icao);
+				});
+			}
+
+			aircraft.marker.setLatLng(coord);
+			aircraft.marker.setRotationAngle(aircraft.track);
+		}
+
+		// refresh aircraft data in the table.
+		const icao = document.createElement('span');
+		icao.className='icao';
+		icao.innerHTML=aircraft.icao;
+
+		const flight = document.createElement('span');
+		flight.className='flight';
+		flight.innerHTML=aircraft.ident;
+
+		const altitude = document.createElement('span');
+		altitude.className='altitude';
+		altitude.innerHTML=aircraft.onGround ? "ground" : `${aircraft.altitude}ft`;
+
+		const speed = document.createElement('span');
+		speed.className='speed';
+		speed.innerHTML=aircraft.groundSpeed ? `${Math.round(aircraft.groundSpeed)}kt` : '';
+
+		aircraft.container.innerHTML='';
+		aircraft.container.appendChild(icao);
+		aircraft.container.appendChild(flight);
+		aircraft.container.appendChild(altitude);
+		aircraft.container.appendChild(speed);
+	}
+
+	// clean outdated aircrafts.
+	const cleanAircrafts = function() {
+		Object.keys(aircraftDict).forEach((key)=>{
+			if (aircraftDict[key].seen.getTime() < new Date().getTime()- 120000) {
+				acContainer.removeChild(aircraftDict[key].container);
+				if (aircraftDict[key].marker != undefined) {
+					aircraftDict[key].marker.remove();
 				}
-			});
-	
-			// refresh aircraft data in the table.
-			Object.keys(aircraftDict).forEach((key)=>{
-				const icao = document.createElement('span');
-				icao.className='icao';
-				icao.innerHTML=aircraftDict[key].icao_hex;
-	
-				const flight = document.createElement('span');
-				flight.className='flight';
-				flight.innerHTML=aircraftDict[key].flight;
-	
-				const altitude = document.createElement('span');
-				altitude.className='altitude';
-				altitude.innerHTML=`${aircraftDict[key].altitude}ft`;
-	
-				const speed = document.createElement('span');
-				speed.className='speed';
-				speed.innerHTML=`${aircraftDict[key].speed}kt`;
-	
-				aircraftDict[key].container.innerHTML='';
-				aircraftDict[key].container.appendChild(icao);
-				aircraftDict[key].container.appendChild(flight);
-				aircraftDict[key].container.appendChild(altitude);
-				aircraftDict[key].container.appendChild(speed);
-			});
+				delete aircraftDict[key];
+			}
+		});
+	}
+
+	const aircraftGetter = function() {
+		loadAircrafts().then(function(aircraftList) {
+			aircraftList.forEach(updateAircraft);
+			cleanAircrafts();
 		});
 	}
 
-	aircraftGetter();
+	// polling the API is the fallback, when the browser or the server cannot stream.
+	const startPolling = function() {
+		aircraftGetter();
+
+		setInterval(aircraftGetter, 2000);
+	}
+
+	if (window.EventSource == undefined) {
+		startPolling();
 
-	this.setInterval(aircraftGetter(), 2000);
+		return;
+	}
+
+	const source = new EventSource(`${window.apiPath.replace(/\/$/, '')}/stream`);
+	let streaming = false;
+
+	source.addEventListener('open', () => {
+		streaming = true;
+	});
+
+	source.addEventListener('aircraft', (event) => {
+		updateAircraft(JSON.parse(event.data));
+	});
+
+	source.addEventListener('error', () => {
+		// the browser reconnects by itself, unless the stream never opened.
+		if (!streaming) {
+			source.close();
+			startPolling();
+		}
+	});
+
+	setInterval(cleanAircrafts, 2000);
 });
 
 function clickAircraft(map, icao) {
@@ -105,13 +143,16 @@ function clickAircraft(map, icao) {
 		child.className = 'aircraft'
 	}
 
-	Object.keys(aircraftDict).forEach((ac) => aircraftDict[ac].marker.setIcon(aircraftMarkerIcon));
+	Object.keys(aircraftDict).forEach((ac) => aircraftDict[ac].marker?.setIcon(aircraftMarkerIcon));
 
 	if (!isHighlight) {
 		line.className = 'aircraft highlight';
-		aircraftDict[icao].marker.setIcon(selectedAircraftMarkerIcon);
 
-		map.panTo(aircraftDict[icao].coordinate);
+		if (aircraftDict[icao].marker != undefined) {
+			aircraftDict[icao].marker.setIcon(selectedAircraftMarkerIcon);
+
+			map.panTo(aircraftDict[icao].coordinate);
+		}
 	}
 }
 
@@ -0,0 +1,384 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/database"
+	"github.com/landru29/adsb1090/internal/errors"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/serialize"
+)
+
+const (
+	errInvalidBounds errors.Error = "invalid bounds (syntax: 'south,west,north,east')"
+
+	// streamBuffer is the number of events queued for a client; a slower client is disconnected.
+	streamBuffer = 256
+
+	// streamKeepAlive is the period of the comments sent to keep the idle connections open.
+	streamKeepAlive = 15 * time.Second
+)
+
+// streamFilter selects the aircraft pushed to a client.
+// The query parameters are 'icao' and 'source' (comma separated lists) and 'bounds' ('south,west,north,east').
+type streamFilter struct {
+	addresses map[model.ICAOAddr]struct{}
+	sources   map[model.Source]struct{}
+	bounds    *[4]float64
+}
+
+func parseStreamFilter(query url.Values) (streamFilter, error) {
+	output := streamFilter{}
+
+	for _, str := range splitQuery(query, "icao") {
+		addr, err := model.ParseICAOAddr(str)
+		if err != nil {
+			return output, err
+		}
+
+		if output.addresses == nil {
+			output.addresses = map[model.ICAOAddr]struct{}{}
+		}
+
+		output.addresses[addr] = struct{}{}
+	}
+
+	for _, str := range splitQuery(query, "source") {
+		var source model.Source
+		if err := source.UnmarshalText([]byte(str)); err != nil {
+			return output, err
+		}
+
+		if output.sources == nil {
+			output.sources = map[model.Source]struct{}{}
+		}
+
+		output.sources[source] = struct{}{}
+	}
+
+	if bounds := splitQuery(query, "bounds"); len(bounds) > 0 {
+		if len(bounds) != len(output.bounds) {
+			return output, errInvalidBounds
+		}
+
+		output.bounds = &[4]float64{}
+
+		for idx, str := range bounds {
+			value, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return output, err
+			}
+
+			output.bounds[idx] = value
+		}
+	}
+
+	return output, nil
+}
+
+// splitQuery is the list of the comma separated values of a query parameter.
+func splitQuery(query url.Values, key string) []string {
+	output := []string{}
+
+	for _, value := range query[key] {
+		for _, str := range strings.Split(value, ",") {
+			if str = strings.TrimSpace(str); str != "" {
+				output = append(output, str)
+			}
+		}
+	}
+
+	return output
+}
+
+func (f streamFilter) match(aircraft model.Aircraft) bool {
+	if _, found := f.addresses[aircraft.Addr]; f.addresses != nil && !found {
+		return false
+	}
+
+	if _, found := f.sources[aircraft.Source]; f.sources != nil && !found {
+		return false
+	}
+
+	if f.bounds != nil {
+		position := aircraft.Position
+
+		return position != nil &&
+			position.Latitude >= f.bounds[0] && position.Longitude >= f.bounds[1] &&
+			position.Latitude <= f.bounds[2] && position.Longitude <= f.bounds[3]
+	}
+
+	return true
+}
+
+// subscriber is a client of the stream.
+type subscriber struct {
+	filter streamFilter
+	events chan []byte
+	known  map[model.ICAOAddr]struct{} /* Aircraft sent in full, to send their changes only. */
+}
+
+// stream stores the aircraft, and pushes their changes to the subscribers.
+type stream struct {
+	mutex       sync.Mutex
+	aircraftDB  *database.ElementStorage[model.ICAOAddr, model.Aircraft]
+	formater    serialize.Serializer
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+func newStream(
+	aircraftDB *database.ElementStorage[model.ICAOAddr, model.Aircraft],
+	formater serialize.Serializer,
+) *stream {
+	return &stream{
+		aircraftDB:  aircraftDB,
+		formater:    formater,
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// subscribe registers a client, with the events of the tracked aircraft as a snapshot.
+func (s *stream) subscribe(filter streamFilter) (*subscriber, [][]byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	output := &subscriber{
+		filter: filter,
+		events: make(chan []byte, streamBuffer),
+		known:  map[model.ICAOAddr]struct{}{},
+	}
+
+	if s.closed {
+		close(output.events)
+
+		return output, nil
+	}
+
+	s.subscribers[output] = struct{}{}
+
+	snapshot := [][]byte{}
+
+	for _, addr := range s.aircraftDB.Keys() {
+		// the element may have expired since the keys were listed.
+		aircraft := s.aircraftDB.Element(addr)
+		if aircraft == nil || !filter.match(*aircraft) {
+			continue
+		}
+
+		event, err := s.event(nil, aircraft)
+		if err != nil {
+			continue
+		}
+
+		output.known[addr] = struct{}{}
+		snapshot = append(snapshot, event)
+	}
+
+	return output, snapshot
+}
+
+func (s *stream) unsubscribe(client *subscriber) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, found := s.subscribers[client]; found {
+		delete(s.subscribers, client)
+		close(client.events)
+	}
+}
+
+// update stores the aircraft, and sends it to the interested subscribers: in full the first time, then its changes.
+// A subscriber that cannot keep up is dropped; the browser reconnects and gets a new snapshot.
+func (s *stream) update(aircraft *model.Aircraft) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous := s.aircraftDB.Element(aircraft.Addr)
+
+	s.aircraftDB.Add(aircraft.Addr, *aircraft)
+
+	// without any subscriber, the aircraft is not serialized.
+	if len(s.subscribers) == 0 {
+		return nil
+	}
+
+	// the events are serialized once, for all the subscribers.
+	events := map[bool][]byte{}
+
+	for client := range s.subscribers {
+		if !client.filter.match(*aircraft) {
+			// the aircraft is sent in full again if it matches later.
+			delete(client.known, aircraft.Addr)
+
+			continue
+		}
+
+		_, known := client.known[aircraft.Addr]
+
+		event, found := events[known]
+		if !found {
+			base := previous
+			if !known {
+				base = nil
+			}
+
+			var err error
+
+			if event, err = s.event(base, aircraft); err != nil {
+				return err
+			}
+
+			events[known] = event
+		}
+
+		if event == nil {
+			continue
+		}
+
+		select {
+		case client.events <- event:
+			client.known[aircraft.Addr] = struct{}{}
+		default:
+			delete(s.subscribers, client)
+			close(client.events)
+		}
+	}
+
+	return nil
+}
+
+// close ends all the subscriptions.
+func (s *stream) close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for client := range s.subscribers {
+		close(client.events)
+	}
+
+	s.subscribers = map[*subscriber]struct{}{}
+	s.closed = true
+}
+
+// fields are the serialized fields of the aircraft, as sent by the JSON API.
+func (s *stream) fields(aircraft *model.Aircraft) (map[string]json.RawMessage, error) {
+	data, err := s.formater.Serialize(aircraft)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []map[string]json.RawMessage{}
+
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, err
+	}
+
+	if len(output) == 0 {
+		return map[string]json.RawMessage{}, nil
+	}
+
+	return output[0], nil
+}
+
+// event is the aircraft in full without a previous state, or its fields changed since; the removed fields are null.
+// The 'icao' field is always set, to merge the event on the client side. Without any change, there is no event.
+func (s *stream) event(previous *model.Aircraft, current *model.Aircraft) ([]byte, error) {
+	currentFields, err := s.fields(current)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous == nil {
+		return json.Marshal(currentFields)
+	}
+
+	previousFields, err := s.fields(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	output := map[string]json.RawMessage{
+		"icao": currentFields["icao"],
+	}
+
+	for key, value := range currentFields {
+		if !bytes.Equal(previousFields[key], value) {
+			output[key] = value
+		}
+	}
+
+	for key := range previousFields {
+		if _, found := currentFields[key]; !found {
+			output[key] = json.RawMessage("null")
+		}
+	}
+
+	if len(output) == 1 {
+		return nil, nil
+	}
+
+	return json.Marshal(output)
+}
+
+// serveStream pushes the aircraft as server-sent events: a snapshot of the tracked aircraft, then their changes.
+func (t *Transporter) serveStream(writer http.ResponseWriter, req *http.Request) {
+	filter, err := parseStreamFilter(req.URL.Query())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		http.Error(writer, "streaming unsupported", http.StatusInternalServerError)
+
+		return
+	}
+
+	client, snapshot := t.stream.subscribe(filter)
+	defer t.stream.unsubscribe(client)
+
+	writer.Header().Set("content-type", "text/event-stream")
+	writer.Header().Set("cache-control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+
+	for _, event := range snapshot {
+		writeEvent(writer, event)
+	}
+
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, _ = writer.Write([]byte(": keep-alive\n\n"))
+		case event, ok := <-client.events:
+			if !ok {
+				return
+			}
+
+			writeEvent(writer, event)
+		}
+
+		flusher.Flush()
+	}
+}
+
+func writeEvent(writer http.ResponseWriter, event []byte) {
+	_, _ = fmt.Fprintf(writer, "event: aircraft\ndata: %s\n\n", event)
+}
@@ -0,0 +1,113 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/database"
+	"github.com/landru29/adsb1090/internal/model"
+	"github.com/landru29/adsb1090/internal/serialize"
+	jsonserializer "github.com/landru29/adsb1090/internal/serialize/json"
+	transporthttp "github.com/landru29/adsb1090/internal/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextEvent reads the data of the next server-sent event.
+func nextEvent(t *testing.T, scanner *bufio.Scanner) map[string]any {
+	t.Helper()
+
+	for scanner.Scan() {
+		if data, found := strings.CutPrefix(scanner.Text(), "data: "); found {
+			output := map[string]any{}
+
+			require.NoError(t, json.Unmarshal([]byte(data), &output))
+
+			return output
+		}
+	}
+
+	require.NoError(t, scanner.Err())
+	require.Fail(t, "stream closed")
+
+	return nil
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	aircraftDB := database.NewElementStorage[model.ICAOAddr, model.Aircraft](
+		ctx,
+		database.ElementWithLifetime[model.ICAOAddr, model.Aircraft](time.Minute),
+	)
+
+	transporter, err := transporthttp.New(
+		ctx,
+		"127.0.0.1:0",
+		"/api",
+		aircraftDB,
+		[]serialize.Serializer{jsonserializer.Serializer{}},
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(transporter)
+	t.Cleanup(server.Close)
+
+	followed := model.Aircraft{Addr: 0x4840d6, Identification: "EZY85MH", Altitude: 38000}
+	other := model.Aircraft{Addr: 0x40621d, Identification: "BAW1"}
+
+	require.NoError(t, transporter.Transport(&followed))
+	require.NoError(t, transporter.Transport(&other))
+
+	t.Run("invalid filter", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := http.Get(server.URL + "/api/stream?bounds=1,2") //nolint: noctx
+		require.NoError(t, err)
+
+		_ = resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/stream?icao=4840D6", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = resp.Body.Close()
+	})
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("content-type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+
+	// The snapshot has the followed aircraft in full.
+	event := nextEvent(t, scanner)
+	assert.Equal(t, "4840D6", event["icao"])
+	assert.Equal(t, "EZY85MH", event["ident"])
+
+	// The other aircraft is filtered out, and the followed aircraft is sent with its changes only.
+	other.Altitude = 12000
+	require.NoError(t, transporter.Transport(&other))
+
+	followed.Altitude = 37000
+	require.NoError(t, transporter.Transport(&followed))
+
+	event = nextEvent(t, scanner)
+	assert.Equal(t, "4840D6", event["icao"])
+	assert.InDelta(t, 37000.0, event["altitude"], 0.1)
+	assert.NotContains(t, event, "ident")
+}
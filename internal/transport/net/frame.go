@@ -0,0 +1,86 @@
+package net
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/landru29/adsb1090/internal/avr"
+	"github.com/landru29/adsb1090/internal/beast"
+	"github.com/landru29/adsb1090/internal/logger"
+	"github.com/landru29/adsb1090/internal/model"
+)
+
+// FrameTransporter is the raw frame net transporter.
+type FrameTransporter struct {
+	transporter *Transporter
+	encode      func(model.Frame) ([]byte, error)
+	name        string
+}
+
+// NewBeast creates a net transporter sending the frames in Beast binary format.
+func NewBeast(
+	ctx context.Context,
+	conf ProtocolConfig,
+	log *slog.Logger,
+) (*FrameTransporter, error) {
+	return newFrameTransporter(ctx, conf, log, "beast", func(frame model.Frame) ([]byte, error) {
+		return beast.Frame{
+			Timestamp: frame.Timestamp,
+			Signal:    frame.Signal,
+			Data:      frame.ModeS,
+		}.MarshalBinary()
+	})
+}
+
+// NewAVR creates a net transporter sending the frames in AVR text format.
+func NewAVR(
+	ctx context.Context,
+	conf ProtocolConfig,
+	log *slog.Logger,
+) (*FrameTransporter, error) {
+	return newFrameTransporter(ctx, conf, log, "avr", func(frame model.Frame) ([]byte, error) {
+		return avr.Marshal(frame), nil
+	})
+}
+
+func newFrameTransporter(
+	ctx context.Context,
+	conf ProtocolConfig,
+	log *slog.Logger,
+	name string,
+	encode func(model.Frame) ([]byte, error),
+) (*FrameTransporter, error) {
+	if log == nil {
+		return nil, logger.ErrMissingLogger
+	}
+
+	output := &FrameTransporter{
+		transporter: &Transporter{
+			log: log.With("frames", name),
+		},
+		encode: encode,
+		name:   name,
+	}
+
+	return output, output.transporter.connect(ctx, conf)
+}
+
+// TransportFrame implements the transport.FrameTransporter interface.
+func (t *FrameTransporter) TransportFrame(frame model.Frame) error {
+	data, err := t.encode(frame)
+	if err != nil {
+		return err
+	}
+
+	return t.transporter.broadcast(data)
+}
+
+// Clients is the number of connected clients.
+func (t *FrameTransporter) Clients() int {
+	return t.transporter.Clients()
+}
+
+// String implements the transport.FrameTransporter interface.
+func (t *FrameTransporter) String() string {
+	return t.name
+}
@@ -0,0 +1,152 @@
+package net_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/landru29/adsb1090/internal/beast"
+	"github.com/landru29/adsb1090/internal/model"
+	transportnet "github.com/landru29/adsb1090/internal/transport/net"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeast(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	data, err := hex.DecodeString("8D4840D6202CC371C32CE0576098")
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	conf := transportnet.NewProtocol("tcp")
+	require.NoError(t, conf.Set("dial>"+listener.Addr().String()))
+
+	transporter, err := transportnet.NewBeast(ctx, conf, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "beast", transporter.String())
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	require.NoError(t, transporter.TransportFrame(model.Frame{
+		ModeS:     data,
+		Timestamp: 0x1a1a,
+		Signal:    42,
+	}))
+
+	frame, err := beast.NewReader(conn).Next()
+	require.NoError(t, err)
+
+	assert.Equal(t, beast.Frame{
+		Type:      beast.FrameTypeModeSLong,
+		Timestamp: 0x1a1a,
+		Signal:    42,
+		Data:      data,
+	}, frame)
+}
+
+func TestAVR(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	data, err := hex.DecodeString("5d4ca92bf0802f")
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	conf := transportnet.NewProtocol("tcp")
+	require.NoError(t, conf.Set("dial>"+listener.Addr().String()))
+
+	transporter, err := transportnet.NewAVR(ctx, conf, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "avr", transporter.String())
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	require.NoError(t, transporter.TransportFrame(model.Frame{ModeS: data}))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+
+	assert.Equal(t, "*5D4CA92BF0802F;\n", line)
+}
+
+func TestDialClientError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	conf := transportnet.NewProtocol("tcp")
+	require.NoError(t, conf.Set("dial>"+listener.Addr().String()))
+
+	transporter, err := transportnet.NewAVR(ctx, conf, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// the peer goes away.
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	data, err := hex.DecodeString("5d4ca92bf0802f")
+	require.NoError(t, err)
+
+	// the writes fail once the peer has reset the connection.
+	for idx := 0; idx < 5; idx++ {
+		_ = transporter.TransportFrame(model.Frame{ModeS: data})
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the dialed client is kept.
+	assert.Equal(t, 1, transporter.Clients())
+}
+
+func TestProtocolDefaultAddr(t *testing.T) {
+	t.Parallel()
+
+	conf := transportnet.NewProtocol("tcp").WithDefaultAddr("0.0.0.0:30002")
+	require.NoError(t, conf.Set("bind>"))
+
+	assert.Equal(t, "0.0.0.0:30002", conf.Addr)
+}
@@ -21,12 +21,19 @@ const (
 	errNoValidFormater localerrors.Error = "no valid formater"
 )
 
+// client is a connection of the transporter.
+type client struct {
+	io.WriteCloser
+	accepted bool /* Accepted in bind mode; dropped on error, as the peer connects again by itself. */
+}
+
 // Transporter is the udp transporter.
 type Transporter struct {
-	clients  []io.WriteCloser
+	clients  []client
 	formater serialize.Serializer
 	mutex    sync.Mutex
 	log      *slog.Logger
+	name     string
 }
 
 // New creates a net transporter.
@@ -52,16 +59,21 @@ func New(
 	output := &Transporter{
 		formater: serial,
 		log:      log,
+		name:     string(conf.ProtocolType),
 	}
 
+	return output, output.connect(ctx, conf)
+}
+
+func (t *Transporter) connect(ctx context.Context, conf ProtocolConfig) error {
 	switch conf.Direction {
 	case protocolBind:
-		return output, output.Bind(ctx, conf.ProtocolType, conf.Addr)
+		return t.Bind(ctx, conf.ProtocolType, conf.Addr)
 	case protocolDial:
-		return output, output.Dial(ctx, conf.ProtocolType, conf.Addr)
+		return t.Dial(ctx, conf.ProtocolType, conf.Addr)
 	}
 
-	return nil, fmt.Errorf("unknown %s: specify 'dial' or 'bind'", conf.Direction)
+	return fmt.Errorf("unknown %s: specify 'dial' or 'bind'", conf.Direction)
 }
 
 // Bind is the net binder.
@@ -108,7 +120,7 @@ func (t *Transporter) bindTCP(ctx context.Context, addr string) error {
 				log.Info("accept connection", "from", conn.RemoteAddr().String())
 
 				t.mutex.Lock()
-				t.clients = append(t.clients, conn)
+				t.clients = append(t.clients, client{WriteCloser: conn, accepted: true})
 				t.mutex.Unlock()
 			}
 		}
@@ -136,7 +148,7 @@ func (t *Transporter) Dial(ctx context.Context, pType protocolType, addr string)
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	t.clients = append(t.clients, server)
+	t.clients = append(t.clients, client{WriteCloser: server})
 
 	log.Info("connection accepted")
 
@@ -156,6 +168,11 @@ func (t *Transporter) Transport(ac *model.Aircraft) error {
 
 	data = append(data, '\n')
 
+	return t.broadcast(data)
+}
+
+// broadcast sends the data to all the clients.
+func (t *Transporter) broadcast(data []byte) error {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
@@ -164,8 +181,12 @@ func (t *Transporter) Transport(ac *model.Aircraft) error {
 		hasError  bool
 	)
 
-	for _, client := range t.clients {
-		_, err = client.Write(data)
+	// the accepted clients in error are dropped, as they will never recover.
+	// The dialed ones are kept: the error may be transient (peer restarting).
+	clients := t.clients[:0]
+
+	for _, current := range t.clients {
+		_, err := current.Write(data)
 
 		if err != nil {
 			t.log.Error("client error", "msg", err)
@@ -173,9 +194,19 @@ func (t *Transporter) Transport(ac *model.Aircraft) error {
 			hasError = true
 
 			globalErr = errors.Wrap(globalErr, err.Error())
+
+			if current.accepted {
+				_ = current.Close()
+
+				continue
+			}
 		}
+
+		clients = append(clients, current)
 	}
 
+	t.clients = clients
+
 	if hasError {
 		return globalErr
 	}
@@ -183,15 +214,23 @@ func (t *Transporter) Transport(ac *model.Aircraft) error {
 	return nil
 }
 
+// Clients is the number of connected clients.
+func (t *Transporter) Clients() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return len(t.clients)
+}
+
 func (t *Transporter) close() {
 	t.mutex.Lock()
-	for _, client := range t.clients {
-		_ = client.Close()
+	for _, current := range t.clients {
+		_ = current.Close()
 	}
 	t.mutex.Unlock()
 }
 
 // String implements the transport.Transporter interface.
 func (t *Transporter) String() string {
-	return "net"
+	return t.name
 }
@@ -29,6 +29,7 @@ type ProtocolConfig struct {
 	Format       string
 	Direction    protocolDirection
 	ProtocolType protocolType
+	defaultAddr  string
 }
 
 // NewProtocol creates a new ProtocolConfig.
@@ -38,6 +39,13 @@ func NewProtocol(pType string) ProtocolConfig {
 	}
 }
 
+// WithDefaultAddr sets the address used when none is specified.
+func (p ProtocolConfig) WithDefaultAddr(addr string) ProtocolConfig {
+	p.defaultAddr = addr
+
+	return p
+}
+
 // String implements the pflag.Value interface.
 func (p *ProtocolConfig) String() string {
 	return fmt.Sprintf(
@@ -54,7 +62,7 @@ func (p *ProtocolConfig) Set(str string) error {
 	actionSplitter := strings.Split(str, ">")
 	switch len(actionSplitter) {
 	case 1:
-		format, addr := parseData(actionSplitter[0])
+		format, addr := p.parseData(actionSplitter[0])
 
 		p.Format = format
 		p.Direction = protocolDial
@@ -62,7 +70,7 @@ func (p *ProtocolConfig) Set(str string) error {
 
 		return nil
 	case 2: //nolint: gomnd
-		format, addr := parseData(actionSplitter[1])
+		format, addr := p.parseData(actionSplitter[1])
 
 		p.Format = format
 		p.Direction = protocolDirection(actionSplitter[0])
@@ -84,8 +92,12 @@ func (p ProtocolConfig) IsValid() bool {
 	return p.Addr != ""
 }
 
-func parseData(str string) (string, string) {
+func (p ProtocolConfig) parseData(str string) (string, string) {
 	addr := defaultAddr
+	if p.defaultAddr != "" {
+		addr = p.defaultAddr
+	}
+
 	format := defaultProtocolFormat
 
 	if str != "" {
@@ -2,6 +2,7 @@
 package screen
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/landru29/adsb1090/internal/model"
@@ -37,6 +38,30 @@ func (t Transporter) Transport(aircraft *model.Aircraft) error {
 	return nil
 }
 
+// TransportEvent implements the transport.EventTransporter interface.
+// The events are only written in the JSON and text formats; the other formats cannot carry them,
+// and the events are logged anyway.
+func (t Transporter) TransportEvent(event model.Event) error {
+	if t.serializer == nil {
+		return serialize.ErrMissingSerializer
+	}
+
+	switch t.serializer.MimeType() {
+	case "application/json":
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s\n", string(data)) //nolint: forbidigo
+
+	case "text/plain":
+		fmt.Printf("EVENT %s\n", event) //nolint: forbidigo
+	}
+
+	return nil
+}
+
 // New creates  a screen serializer.
 func New(serializer serialize.Serializer) (*Transporter, error) {
 	if serializer == nil {
@@ -8,3 +8,15 @@ type Transporter interface {
 	Transport(ac *model.Aircraft) error
 	String() string
 }
+
+// FrameTransporter is the raw frame transporter.
+type FrameTransporter interface {
+	TransportFrame(frame model.Frame) error
+	String() string
+}
+
+// EventTransporter is the aircraft event transporter.
+type EventTransporter interface {
+	TransportEvent(event model.Event) error
+	String() string
+}